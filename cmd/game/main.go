@@ -1,32 +1,187 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"io/fs"
 	"log"
+	"time"
 
+	"github.com/EchoSingh/space-shooter/internal/config"
 	"github.com/EchoSingh/space-shooter/internal/game"
+	"github.com/EchoSingh/space-shooter/internal/highscore"
+	"github.com/EchoSingh/space-shooter/internal/input"
+	"github.com/EchoSingh/space-shooter/internal/level"
+	"github.com/EchoSingh/space-shooter/internal/replay"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
-const (
-	screenWidth  = 800
-	screenHeight = 600
-	gameTitle    = "Space Shooter"
-)
-
 func main() {
+	configPath := flag.String("config", "configs/game.yaml", "path to the game configuration file")
+	seed := flag.Int64("seed", 0, "random seed for every run (0 picks a new seed per run)")
+	recordPath := flag.String("record", "", "record the session's input to a replay file")
+	replayPath := flag.String("replay", "", "play back a replay file")
+	headless := flag.Bool("headless", false, "with -replay, verify the replay without opening a window")
+	levelPath := flag.String("level", "", "play the waves in a level file before endless mode")
+	scoresPath := flag.String("scores", "", "high-score file (default highscores.json in the user config directory)")
+	flag.Parse()
+
+	// Load configuration, falling back to defaults when the file is absent
+	cfg, err := config.Load(*configPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Printf("Config %s not found, using defaults", *configPath)
+		cfg = config.Default()
+	} else if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	var lvl *level.Level
+	if *levelPath != "" {
+		if lvl, err = level.Load(*levelPath); err != nil {
+			log.Fatalf("Failed to load level: %v", err)
+		}
+	}
+
+	if *replayPath != "" {
+		runReplay(cfg, lvl, *replayPath, *headless)
+		return
+	}
+
+	// Recordings need a fixed seed so they can be played back
+	var src input.Source = input.NewKeyboard()
+	var recorder *replay.Recorder
+	if *recordPath != "" {
+		if *seed == 0 {
+			*seed = time.Now().UnixNano()
+		}
+		recorder = replay.NewRecorder(src, *seed)
+		recorder.SetSetup(replay.SetupOf(cfg, lvl))
+		src = recorder
+	}
+
 	// Initialize the game
-	g, err := game.NewGame(screenWidth, screenHeight)
+	g, err := game.NewGame(cfg, src, *seed)
 	if err != nil {
 		log.Fatalf("Failed to initialize game: %v", err)
 	}
+	if err := g.SetLevel(lvl); err != nil {
+		log.Fatalf("Failed to load level: %v", err)
+	}
+	table := loadHighScores(g, cfg, *scoresPath)
+	if recorder != nil {
+		recorder.SetHighScores(table.Scores())
+	}
 
-	// Set window properties
-	ebiten.SetWindowSize(screenWidth, screenHeight)
-	ebiten.SetWindowTitle(gameTitle)
-	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	setupWindow(cfg)
 
 	// Run the game
 	if err := ebiten.RunGame(g); err != nil {
 		log.Fatalf("Game error: %v", err)
 	}
+
+	if recorder != nil {
+		if err := replay.Save(*recordPath, recorder.Finish(finalState(g))); err != nil {
+			log.Fatalf("Failed to save replay: %v", err)
+		}
+		log.Printf("Replay saved to %s", *recordPath)
+	}
+}
+
+func setupWindow(cfg *config.Config) {
+	ebiten.SetWindowSize(cfg.Game.Width, cfg.Game.Height)
+	ebiten.SetWindowTitle(cfg.Game.Title)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	ebiten.SetTPS(cfg.Game.FPS)
+}
+
+// loadHighScores gives the game its high-score table and returns it.
+// Problems reading the table are logged and play goes on, with an empty
+// table if need be.
+func loadHighScores(g *game.Game, cfg *config.Config, path string) *highscore.Table {
+	if path == "" {
+		var err error
+		if path, err = highscore.DefaultPath(); err != nil {
+			log.Printf("No config directory for high scores, they won't be saved: %v", err)
+		}
+	}
+
+	table := highscore.New(cfg.Game.HighScores)
+	if path != "" {
+		var err error
+		if table, err = highscore.Load(path, cfg.Game.HighScores); err != nil {
+			log.Printf("Failed to load high scores: %v", err)
+		}
+	}
+	g.SetHighScores(table, path)
+	return table
+}
+
+// runReplay plays back a recorded session and checks it ends as recorded.
+// It refuses to start with a config or level other than the ones the
+// session was recorded with. The high-score table
+// is rebuilt from the recording and never saved, so the same runs ask
+// for a name whatever the table holds now.
+func runReplay(cfg *config.Config, lvl *level.Level, path string, headless bool) {
+	rep, err := replay.Load(path)
+	if err != nil {
+		log.Fatalf("Failed to load replay: %v", err)
+	}
+	if err := rep.CheckSetup(replay.SetupOf(cfg, lvl)); err != nil {
+		log.Fatalf("Can't play back %s: %v", path, err)
+	}
+
+	playback := replay.NewPlayback(rep)
+	g, err := game.NewGame(cfg, playback, rep.Seed)
+	if err != nil {
+		log.Fatalf("Failed to initialize game: %v", err)
+	}
+	if err := g.SetLevel(lvl); err != nil {
+		log.Fatalf("Failed to load level: %v", err)
+	}
+	g.SetHighScores(highscore.FromScores(cfg.Game.HighScores, rep.HighScores), "")
+
+	if headless {
+		for !playback.Done() {
+			if err := g.Update(); err != nil {
+				log.Fatalf("Game error: %v", err)
+			}
+		}
+	} else {
+		setupWindow(cfg)
+		if err := ebiten.RunGame(&replayGame{Game: g, playback: playback}); err != nil {
+			log.Fatalf("Game error: %v", err)
+		}
+		if !playback.Done() {
+			log.Printf("Replay stopped before the end")
+			return
+		}
+	}
+
+	if err := rep.Verify(finalState(g)); err != nil {
+		log.Fatalf("Replay verification failed: %v", err)
+	}
+	log.Printf("Replay verified: score %d after %d ticks", rep.Final.Score, rep.Final.Tick)
+}
+
+// replayGame stops the game once the replay runs out of input
+type replayGame struct {
+	*game.Game
+	playback *replay.Playback
+}
+
+func (r *replayGame) Update() error {
+	if r.playback.Done() {
+		return ebiten.Termination
+	}
+	return r.Game.Update()
+}
+
+// finalState captures the state a replay is verified against
+func finalState(g *game.Game) replay.State {
+	state := replay.State{Tick: g.Tick()}
+	if p := g.Player(); p != nil {
+		state.Score = p.GetScore()
+		state.Health = p.Health.Current
+	}
+	return state
 }
@@ -0,0 +1,222 @@
+// Package assets embeds the game's sprite sheets and cuts them into
+// sprites as the manifest describes. Sheets are decoded when the library
+// is loaded, but only copied into the shared texture atlas the first time
+// a sprite is drawn, so a library can be loaded without a graphics
+// context.
+package assets
+
+import (
+	"embed"
+	"fmt"
+	"image"
+	_ "image/png" // sprite sheets are PNGs
+	"io/fs"
+	"path"
+
+	"github.com/EchoSingh/space-shooter/internal/render"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ManifestFile is the manifest's name within the sprite directory
+const ManifestFile = "manifest.yaml"
+
+// Sprite names the game draws with
+const (
+	SpritePlayer       = "player"
+	SpriteBulletPlayer = "bullet_player"
+	SpriteBulletEnemy  = "bullet_enemy"
+	SpriteMissile      = "missile"
+)
+
+// EnemySprite returns the name of an enemy type's sprite
+func EnemySprite(enemyType string) string {
+	return "enemy_" + enemyType
+}
+
+// Animation names ship sprites provide
+const (
+	AnimIdle      = "idle"
+	AnimBankLeft  = "bank_left"
+	AnimBankRight = "bank_right"
+	AnimHit       = "hit"
+	AnimDeath     = "death"
+)
+
+//go:embed sprites
+var files embed.FS
+
+// Frame is one frame of a sprite: where it lies in its sheet, and the
+// part of it that collides, relative to the frame
+type Frame struct {
+	Rect   image.Rectangle
+	Hitbox image.Rectangle
+}
+
+// Animation steps through a sprite's frames at FPS frames a second
+type Animation struct {
+	Name   string
+	Frames []int
+	FPS    float64
+	Loop   bool
+}
+
+// Duration returns how long one pass through the animation takes
+func (a *Animation) Duration() float64 {
+	return float64(len(a.Frames)) / a.FPS
+}
+
+// Sprite is a set of same-sized frames drawn around a pivot, and the
+// animations made from them
+type Sprite struct {
+	Name       string
+	Size       image.Point
+	Pivot      image.Point
+	Frames     []Frame
+	Animations map[string]*Animation
+
+	sheet  image.Image
+	images []*ebiten.Image
+}
+
+// Animation returns the named animation, or nil if the sprite has none
+func (s *Sprite) Animation(name string) *Animation {
+	return s.Animations[name]
+}
+
+// Image returns frame i's image, copying the sprite's frames into the
+// shared atlas on first use
+func (s *Sprite) Image(i int) *ebiten.Image {
+	if s.images == nil {
+		s.upload(render.SharedAtlas())
+	}
+	return s.images[i]
+}
+
+// upload copies each frame into atlas, reusing frames another library
+// already put there. Frames that don't fit get an image of their own,
+// which still only happens once.
+func (s *Sprite) upload(atlas *render.Atlas) {
+	sub := s.sheet.(interface {
+		SubImage(r image.Rectangle) image.Image
+	})
+
+	s.images = make([]*ebiten.Image, len(s.Frames))
+	for i, f := range s.Frames {
+		name := fmt.Sprintf("%s/%d", s.Name, i)
+		if img, ok := atlas.Region(name); ok && img.Bounds().Size() == s.Size {
+			s.images[i] = img
+			continue
+		}
+
+		frame := sub.SubImage(f.Rect)
+		img, err := atlas.Add(name, frame)
+		if err != nil {
+			img = ebiten.NewImageFromImage(frame)
+		}
+		s.images[i] = img
+	}
+}
+
+// Library holds every sprite in the manifest by name
+type Library struct {
+	sprites map[string]*Sprite
+}
+
+// Load loads the sprites embedded in the game
+func Load() (*Library, error) {
+	sprites, err := fs.Sub(files, "sprites")
+	if err != nil {
+		return nil, err
+	}
+	return LoadFS(sprites)
+}
+
+// LoadFS loads the sprites described by the manifest at the root of fsys,
+// decoding each sheet it names
+func LoadFS(fsys fs.FS) (*Library, error) {
+	data, err := fs.ReadFile(fsys, ManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	m, err := ParseManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ManifestFile, err)
+	}
+
+	sheets := make(map[string]image.Image)
+	l := &Library{sprites: make(map[string]*Sprite, len(m.Sprites))}
+	for _, name := range sortedKeys(m.Sprites) {
+		spec := m.Sprites[name]
+		sheet, ok := sheets[spec.Sheet]
+		if !ok {
+			if sheet, err = loadSheet(fsys, spec.Sheet); err != nil {
+				return nil, err
+			}
+			sheets[spec.Sheet] = sheet
+		}
+
+		sprite, err := newSprite(name, spec, sheet)
+		if err != nil {
+			return nil, err
+		}
+		l.sprites[name] = sprite
+	}
+	return l, nil
+}
+
+// Sprite returns the named sprite, or nil if there is none
+func (l *Library) Sprite(name string) *Sprite {
+	return l.sprites[name]
+}
+
+// loadSheet decodes a sprite sheet
+func loadSheet(fsys fs.FS, name string) (image.Image, error) {
+	f, err := fsys.Open(path.Clean(name))
+	if err != nil {
+		return nil, fmt.Errorf("reading sheet: %w", err)
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("decoding sheet %s: %w", name, err)
+	}
+	if _, ok := img.(interface {
+		SubImage(r image.Rectangle) image.Image
+	}); !ok {
+		return nil, fmt.Errorf("decoding sheet %s: frames can't be cut from a %T", name, img)
+	}
+	return img, nil
+}
+
+// newSprite cuts a sprite's frames from its sheet
+func newSprite(name string, spec SpriteSpec, sheet image.Image) (*Sprite, error) {
+	size := image.Pt(spec.Size[0], spec.Size[1])
+	s := &Sprite{
+		Name:       name,
+		Size:       size,
+		Pivot:      image.Pt(spec.Pivot[0], spec.Pivot[1]),
+		Frames:     make([]Frame, len(spec.Frames)),
+		Animations: make(map[string]*Animation, len(spec.Animations)),
+		sheet:      sheet,
+	}
+
+	for i, f := range spec.Frames {
+		at := image.Pt(f.At[0], f.At[1]).Add(sheet.Bounds().Min)
+		rect := image.Rectangle{Min: at, Max: at.Add(size)}
+		if !rect.In(sheet.Bounds()) {
+			return nil, fmt.Errorf("sprite %s: frame %d at %v lies outside %s", name, i, f.At, spec.Sheet)
+		}
+
+		hitbox := image.Rectangle{Max: size}
+		if f.Hitbox != nil {
+			hitbox = image.Rect(f.Hitbox[0], f.Hitbox[1], f.Hitbox[0]+f.Hitbox[2], f.Hitbox[1]+f.Hitbox[3])
+		}
+		s.Frames[i] = Frame{Rect: rect, Hitbox: hitbox}
+	}
+
+	for anim, a := range spec.Animations {
+		s.Animations[anim] = &Animation{Name: anim, Frames: a.Frames, FPS: a.FPS, Loop: a.Loop}
+	}
+	return s, nil
+}
@@ -0,0 +1,132 @@
+package assets
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+)
+
+func TestEmbeddedSprites(t *testing.T) {
+	lib, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	ships := []string{SpritePlayer}
+	for _, name := range config.EnemyTypeNames() {
+		ships = append(ships, EnemySprite(name))
+	}
+	for _, name := range ships {
+		s := lib.Sprite(name)
+		if s == nil {
+			t.Errorf("Expected a %s sprite", name)
+			continue
+		}
+		for _, anim := range []string{AnimIdle, AnimBankLeft, AnimBankRight, AnimHit, AnimDeath} {
+			if s.Animation(anim) == nil {
+				t.Errorf("Expected %s to have a %s animation", name, anim)
+			}
+		}
+		if s.Animation(AnimDeath).Loop {
+			t.Errorf("Expected %s's death to play once", name)
+		}
+	}
+
+	for _, name := range []string{SpriteBulletPlayer, SpriteBulletEnemy, SpriteMissile} {
+		if s := lib.Sprite(name); s == nil || s.Animation(AnimIdle) == nil {
+			t.Errorf("Expected a %s sprite with an idle animation", name)
+		}
+	}
+}
+
+// sheet encodes a blank PNG sheet of the given size
+func sheet(t *testing.T, w, h int) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestLoadFS(t *testing.T) {
+	manifest := `
+sprites:
+  ship:
+    sheet: ships.png
+    size: [16, 8]
+    pivot: [8, 4]
+    frames:
+      - {at: [0, 0]}
+      - {at: [16, 0], hitbox: [2, 0, 12, 8]}
+    animations:
+      idle: {frames: [0, 1], fps: 4, loop: true}
+`
+	lib, err := LoadFS(fstest.MapFS{
+		ManifestFile: {Data: []byte(manifest)},
+		"ships.png":  {Data: sheet(t, 32, 8)},
+	})
+	if err != nil {
+		t.Fatalf("LoadFS failed: %v", err)
+	}
+
+	s := lib.Sprite("ship")
+	if s == nil {
+		t.Fatal("Expected the ship sprite")
+	}
+	if s.Frames[1].Rect != image.Rect(16, 0, 32, 8) {
+		t.Errorf("Expected the second frame beside the first, got %v", s.Frames[1].Rect)
+	}
+	if s.Frames[0].Hitbox != image.Rect(0, 0, 16, 8) || s.Frames[1].Hitbox != image.Rect(2, 0, 14, 8) {
+		t.Errorf("Expected a whole frame then a narrowed hitbox, got %v and %v", s.Frames[0].Hitbox, s.Frames[1].Hitbox)
+	}
+	if d := s.Animation(AnimIdle).Duration(); d != 0.5 {
+		t.Errorf("Expected a half second idle, got %g", d)
+	}
+	if lib.Sprite("missing") != nil {
+		t.Error("Expected no sprite for an unknown name")
+	}
+
+	// A frame past the edge of its sheet is caught when the sheet loads
+	_, err = LoadFS(fstest.MapFS{
+		ManifestFile: {Data: []byte(manifest)},
+		"ships.png":  {Data: sheet(t, 24, 8)},
+	})
+	if err == nil || !strings.Contains(err.Error(), "frame 1") {
+		t.Errorf("Expected frame 1 to lie outside the sheet, got %v", err)
+	}
+}
+
+func TestParseManifestRejectsBadValues(t *testing.T) {
+	sprite := func(body string) string {
+		return "sprites:\n  ship:\n    sheet: s.png\n" + body
+	}
+	frames := "    frames:\n      - {at: [0, 0]}\n"
+
+	tests := []struct {
+		name  string
+		yaml  string
+		field string
+	}{
+		{"no sprites", "sprites: {}\n", "sprites must"},
+		{"zero size", sprite("    size: [0, 8]\n" + frames), "sprites.ship.size"},
+		{"pivot outside", sprite("    size: [8, 8]\n    pivot: [9, 0]\n" + frames), "sprites.ship.pivot"},
+		{"no frames", sprite("    size: [8, 8]\n"), "sprites.ship.frames"},
+		{"short hitbox", sprite("    size: [8, 8]\n    frames:\n      - {at: [0, 0], hitbox: [0, 0, 4]}\n"), "sprites.ship.frames[0].hitbox"},
+		{"hitbox outside", sprite("    size: [8, 8]\n    frames:\n      - {at: [0, 0], hitbox: [4, 0, 8, 8]}\n"), "sprites.ship.frames[0].hitbox"},
+		{"missing frame", sprite("    size: [8, 8]\n" + frames + "    animations:\n      idle: {frames: [1], fps: 1}\n"), "sprites.ship.animations.idle.frames"},
+		{"zero fps", sprite("    size: [8, 8]\n" + frames + "    animations:\n      idle: {frames: [0]}\n"), "sprites.ship.animations.idle.fps"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseManifest([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.field) {
+				t.Errorf("Expected an error mentioning %q, got %v", tt.field, err)
+			}
+		})
+	}
+}
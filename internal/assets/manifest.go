@@ -0,0 +1,117 @@
+package assets
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest describes every sprite cut from the sprite sheets
+type Manifest struct {
+	Sprites map[string]SpriteSpec `yaml:"sprites"`
+}
+
+// SpriteSpec describes one sprite: the sheet its frames are cut from,
+// the frame size and the pivot frames are drawn around, in pixels
+type SpriteSpec struct {
+	Sheet      string                   `yaml:"sheet"`
+	Size       [2]int                   `yaml:"size"`
+	Pivot      [2]int                   `yaml:"pivot"`
+	Frames     []FrameSpec              `yaml:"frames"`
+	Animations map[string]AnimationSpec `yaml:"animations"`
+}
+
+// FrameSpec places a frame in its sheet. Hitbox is x, y, width and height
+// within the frame; left out, the whole frame collides.
+type FrameSpec struct {
+	At     [2]int `yaml:"at"`
+	Hitbox []int  `yaml:"hitbox"`
+}
+
+// AnimationSpec lists the frames an animation steps through at FPS frames
+// a second. Looping animations run until another is played.
+type AnimationSpec struct {
+	Frames []int   `yaml:"frames"`
+	FPS    float64 `yaml:"fps"`
+	Loop   bool    `yaml:"loop"`
+}
+
+// ParseManifest decodes and validates YAML manifest data
+func ParseManifest(data []byte) (*Manifest, error) {
+	m := &Manifest{}
+	if err := yaml.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Validate checks that every sprite's frames and animations make sense.
+// Whether frames fit their sheet is checked when the sheet is loaded.
+func (m *Manifest) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(len(m.Sprites) > 0, "sprites must list at least one sprite")
+
+	for _, name := range sortedKeys(m.Sprites) {
+		s := m.Sprites[name]
+		field := "sprites." + name
+		check(s.Sheet != "", "%s.sheet must be set", field)
+		check(s.Size[0] > 0 && s.Size[1] > 0, "%s.size must be positive, got %v", field, s.Size)
+		check(s.Pivot[0] >= 0 && s.Pivot[0] <= s.Size[0] && s.Pivot[1] >= 0 && s.Pivot[1] <= s.Size[1],
+			"%s.pivot must lie within the frame, got %v", field, s.Pivot)
+		check(len(s.Frames) > 0, "%s.frames must list at least one frame", field)
+
+		for i, f := range s.Frames {
+			field := fmt.Sprintf("%s.frames[%d]", field, i)
+			check(f.At[0] >= 0 && f.At[1] >= 0, "%s.at must not be negative, got %v", field, f.At)
+			if f.Hitbox == nil {
+				continue
+			}
+			if len(f.Hitbox) != 4 {
+				check(false, "%s.hitbox must be x, y, width, height, got %v", field, f.Hitbox)
+				continue
+			}
+			x, y, w, h := f.Hitbox[0], f.Hitbox[1], f.Hitbox[2], f.Hitbox[3]
+			check(w > 0 && h > 0, "%s.hitbox size must be positive, got %v", field, f.Hitbox)
+			check(x >= 0 && y >= 0 && x+w <= s.Size[0] && y+h <= s.Size[1],
+				"%s.hitbox must lie within the frame, got %v", field, f.Hitbox)
+		}
+
+		for _, anim := range sortedKeys(s.Animations) {
+			a := s.Animations[anim]
+			field := fmt.Sprintf("%s.animations.%s", field, anim)
+			check(len(a.Frames) > 0, "%s.frames must list at least one frame", field)
+			check(a.FPS > 0, "%s.fps must be positive, got %g", field, a.FPS)
+			for _, f := range a.Frames {
+				check(f >= 0 && f < len(s.Frames), "%s.frames has frame %d, but the sprite has %d", field, f, len(s.Frames))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid manifest: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// sortedKeys returns a map's keys in order, so errors come out the same
+// way every time
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,242 @@
+// Package background draws the scenery scrolling behind the play:
+// layers of distant stars, nebula clouds, planets, near stars and debris,
+// generated from the run's seed. Layers further back scroll more slowly,
+// and everything speeds up as the player gets through the level.
+package background
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/render"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// seedSalt mixes the run's seed so the background never draws the same
+// numbers as the game it is behind
+const seedSalt = 0x5eed_ba5e
+
+// kind is what a field's items are drawn as
+type kind int
+
+const (
+	kindStar kind = iota
+	kindCloud
+	kindPlanet
+	kindDebris
+)
+
+// Depths of each field within its layer, back to front
+const (
+	zSky = iota
+	zStars
+	zClouds
+)
+
+const (
+	zPlanets = iota
+	zNearStars
+	zDebris
+)
+
+// item is one piece of scenery, centred on x, y
+type item struct {
+	x, y    float64
+	w, h    float64
+	speed   float64
+	angle   float64
+	spin    float64
+	variant int
+	tint    ebiten.ColorScale
+}
+
+// field is one parallax layer of scenery. Depth scales its scroll speed,
+// and items leaving the bottom come back up to gap screens above the top.
+type field struct {
+	bg    *Background
+	kind  kind
+	layer render.Layer
+	z     float64
+	depth float64
+	gap   float64
+	items []item
+}
+
+// Background is the scrolling scenery for a run
+type Background struct {
+	cfg    config.BackgroundConfig
+	theme  Theme
+	width  float64
+	height float64
+	rng    *rand.Rand
+	speed  float64
+	fields []*field
+	sky    render.Drawable
+
+	// The theme's colours, boxed once rather than every frame
+	skyColor    color.Color
+	debrisColor color.Color
+
+	batch *render.Batch
+	op    ebiten.DrawImageOptions
+}
+
+// New creates a width by height background in the named theme, laid out
+// from seed. It has its own random source, so it never changes what the
+// game's random source deals out.
+func New(cfg config.BackgroundConfig, theme string, seed int64, width, height int) *Background {
+	b := &Background{
+		cfg:    cfg,
+		theme:  ThemeFor(theme),
+		width:  float64(width),
+		height: float64(height),
+		rng:    rand.New(rand.NewSource(seed ^ seedSalt)),
+	}
+	b.sky = render.DrawFunc(b.drawSky)
+	b.skyColor = b.theme.Sky
+	b.debrisColor = b.theme.DebrisColor
+	b.op.Filter = ebiten.FilterLinear
+
+	t := b.theme
+	b.fields = []*field{
+		b.newField(kindStar, render.LayerBackground, zStars, 0.3, 0, t.Stars),
+		b.newField(kindCloud, render.LayerBackground, zClouds, 0.15, 0.5, t.Clouds),
+		b.newField(kindPlanet, render.LayerParallax, zPlanets, 0.45, 1.5, t.Planets),
+		b.newField(kindStar, render.LayerParallax, zNearStars, 0.8, 0, t.NearStars),
+		b.newField(kindDebris, render.LayerParallax, zDebris, 1, 0.5, t.Debris),
+	}
+	b.Update(0, 0)
+	return b
+}
+
+// newField scatters count items of a kind over the screen
+func (b *Background) newField(k kind, layer render.Layer, z, depth, gap float64, count int) *field {
+	f := &field{bg: b, kind: k, layer: layer, z: z, depth: depth, gap: gap}
+	f.items = make([]item, count)
+	for i := range f.items {
+		f.items[i] = b.newItem(k, depth)
+		f.items[i].x = b.rng.Float64() * b.width
+		f.items[i].y = b.rng.Float64() * b.height
+	}
+	return f
+}
+
+// newItem creates an item of a kind with its size, speed and tint. Near
+// stars are bigger and brighter than distant ones.
+func (b *Background) newItem(k kind, depth float64) item {
+	t := b.theme
+	it := item{speed: 0.8 + b.rng.Float64()*0.4}
+
+	switch k {
+	case kindStar:
+		size := math.Floor(1 + b.rng.Float64()*2*depth + depth)
+		it.w, it.h = size, size
+		c := t.StarColor
+		c.A = uint8(100 + b.rng.Intn(155))
+		it.tint = render.Scale(c)
+	case kindCloud:
+		it.h = b.height * (0.3 + b.rng.Float64()*0.4)
+		it.w = it.h * (1.2 + b.rng.Float64()*0.8)
+		it.variant = b.rng.Intn(cloudVariants)
+		it.tint = render.Scale(t.CloudColors[b.rng.Intn(len(t.CloudColors))])
+	case kindPlanet:
+		it.w = 40 + b.rng.Float64()*100
+		it.h = it.w
+		it.speed = 1
+		it.variant = b.rng.Intn(planetVariants)
+		it.tint = render.Scale(t.PlanetColors[b.rng.Intn(len(t.PlanetColors))])
+	case kindDebris:
+		it.w = 4 + b.rng.Float64()*10
+		it.h = it.w * (0.5 + b.rng.Float64()*0.5)
+		it.angle = b.rng.Float64() * 2 * math.Pi
+		it.spin = (b.rng.Float64()*2 - 1) * 1.5
+	}
+	return it
+}
+
+// Theme returns the name of the background's theme
+func (b *Background) Theme() string {
+	return b.theme.Name
+}
+
+// Update scrolls the background. Progress runs from 0 at the start of
+// the level to 1 at its end and speeds the scrolling up.
+func (b *Background) Update(dt, progress float64) {
+	progress = math.Max(0, math.Min(1, progress))
+	b.speed = b.cfg.ScrollSpeed * (1 + b.cfg.ProgressBoost*progress)
+
+	for _, f := range b.fields {
+		f.update(dt, b.speed*f.depth)
+	}
+}
+
+// update moves the field's items down, sending any that have left the
+// bottom back above the top at a new position
+func (f *field) update(dt, speed float64) {
+	b := f.bg
+	for i := range f.items {
+		it := &f.items[i]
+		it.y += speed * it.speed * dt
+		it.angle += it.spin * dt
+
+		extent := math.Max(it.w, it.h) / 2
+		if it.y-extent > b.height {
+			it.y = -extent - b.rng.Float64()*f.gap*b.height
+			it.x = b.rng.Float64() * b.width
+		}
+	}
+}
+
+// Submit queues the sky and every field in its layer
+func (b *Background) Submit(q *render.Queue) {
+	q.Submit(render.LayerBackground, zSky, b.sky)
+	for _, f := range b.fields {
+		if len(f.items) > 0 {
+			q.Submit(f.layer, f.z, f)
+		}
+	}
+}
+
+// drawSky fills the screen with the theme's sky colour
+func (b *Background) drawSky(screen *ebiten.Image) {
+	screen.Fill(b.skyColor)
+}
+
+// Draw draws the field's items
+func (f *field) Draw(screen *ebiten.Image) {
+	b := f.bg
+	switch f.kind {
+	case kindStar:
+		if b.batch == nil {
+			b.batch = render.NewBatch(render.SharedAtlas().White())
+		}
+		for _, it := range f.items {
+			b.batch.Quad(it.x-it.w/2, it.y-it.h/2, it.w, it.h, it.tint)
+		}
+		b.batch.Flush(screen)
+	case kindCloud:
+		for i := range f.items {
+			b.drawTexture(screen, sharedTextures().clouds[f.items[i].variant], &f.items[i])
+		}
+	case kindPlanet:
+		for i := range f.items {
+			b.drawTexture(screen, sharedTextures().planets[f.items[i].variant], &f.items[i])
+		}
+	case kindDebris:
+		for _, it := range f.items {
+			render.FillRectRotated(screen, it.x, it.y, it.w, it.h, it.angle, b.debrisColor)
+		}
+	}
+}
+
+// drawTexture stretches tex over the item and tints it
+func (b *Background) drawTexture(screen *ebiten.Image, tex *ebiten.Image, it *item) {
+	size := tex.Bounds().Size()
+	b.op.GeoM.Reset()
+	b.op.GeoM.Scale(it.w/float64(size.X), it.h/float64(size.Y))
+	b.op.GeoM.Translate(it.x-it.w/2, it.y-it.h/2)
+	b.op.ColorScale = it.tint
+	screen.DrawImage(tex, &b.op)
+}
@@ -0,0 +1,152 @@
+package background
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/render"
+)
+
+func newTest(theme string, seed int64) *Background {
+	return New(config.Default().Background, theme, seed, 800, 600)
+}
+
+// positions lists where every item in the background is
+func positions(b *Background) [][2]float64 {
+	var out [][2]float64
+	for _, f := range b.fields {
+		for _, it := range f.items {
+			out = append(out, [2]float64{it.x, it.y})
+		}
+	}
+	return out
+}
+
+func samePositions(a, b [][2]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSameSeedSameBackground(t *testing.T) {
+	a, b := newTest(config.ThemeNebula, 42), newTest(config.ThemeNebula, 42)
+	for i := 0; i < 600; i++ {
+		a.Update(1.0/60, 0.5)
+		b.Update(1.0/60, 0.5)
+	}
+	if !samePositions(positions(a), positions(b)) {
+		t.Error("Expected the same seed to scroll the same way")
+	}
+
+	if samePositions(positions(newTest(config.ThemeNebula, 7)), positions(newTest(config.ThemeNebula, 8))) {
+		t.Error("Expected different seeds to lay out differently")
+	}
+}
+
+func TestThemes(t *testing.T) {
+	for _, name := range config.ThemeNames() {
+		b := newTest(name, 1)
+		if b.Theme() != name {
+			t.Errorf("Expected the %s theme, got %s", name, b.Theme())
+		}
+		if len(b.fields[0].items) == 0 {
+			t.Errorf("Expected %s to have distant stars", name)
+		}
+	}
+	if newTest("void", 1).Theme() != config.ThemeDeepSpace {
+		t.Error("Expected an unknown theme to fall back to deep space")
+	}
+
+	nebula, asteroids := ThemeFor(config.ThemeNebula), ThemeFor(config.ThemeAsteroidField)
+	if nebula.Clouds <= asteroids.Clouds || asteroids.Debris <= nebula.Debris {
+		t.Error("Expected the nebula cloudier and the asteroid field rockier")
+	}
+}
+
+func TestItemsWrapToTop(t *testing.T) {
+	b := newTest(config.ThemeDeepSpace, 3)
+	f := b.fields[0]
+	f.items[0].y = b.height + 10
+	b.Update(0, 0)
+
+	if y := f.items[0].y; y > 0 {
+		t.Errorf("Expected a star past the bottom to come back above the top, got y %g", y)
+	}
+
+	// Stars keep moving for a long time without ever leaving for good
+	for i := 0; i < 6000; i++ {
+		b.Update(1.0/60, 1)
+	}
+	for _, f := range b.fields {
+		for _, it := range f.items {
+			if it.y > b.height+it.h || it.y < -b.height*(f.gap+1) || it.x < 0 || it.x > b.width {
+				t.Fatalf("Expected items to stay near the screen, got one at %g, %g", it.x, it.y)
+			}
+		}
+	}
+}
+
+func TestProgressSpeedsUpScrolling(t *testing.T) {
+	cfg := config.Default().Background
+	moved := func(progress float64) float64 {
+		b := newTest(config.ThemeDeepSpace, 5)
+		it := &b.fields[3].items[0]
+		it.y = 0
+		b.Update(0.1, progress)
+		return it.y
+	}
+
+	start, end := moved(0), moved(1)
+	if start <= 0 {
+		t.Fatal("Expected the background to scroll down")
+	}
+	if want := start * (1 + cfg.ProgressBoost); end < want-1e-9 || end > want+1e-9 {
+		t.Errorf("Expected %g at the end of the level, got %g", want, end)
+	}
+	if moved(2) != end {
+		t.Error("Expected progress past the end to be capped")
+	}
+}
+
+func TestSubmitQueuesLayers(t *testing.T) {
+	b := newTest(config.ThemeAsteroidField, 1)
+	q := render.NewQueue()
+	b.Submit(q)
+
+	// The sky, distant stars and clouds at the back; planets, near
+	// stars and debris in the parallax layer
+	if q.Len(render.LayerBackground) != 3 || q.Len(render.LayerParallax) != 3 {
+		t.Errorf("Expected 3 background and 3 parallax drawables, got %d and %d",
+			q.Len(render.LayerBackground), q.Len(render.LayerParallax))
+	}
+	if q.Len(render.LayerGameplay) != 0 {
+		t.Error("Expected nothing in the gameplay layer")
+	}
+	q.Reset()
+}
+
+func TestGeneratedTextures(t *testing.T) {
+	cloud := cloudImage(rand.New(rand.NewSource(textureSeed)))
+	if c := cloud.RGBAAt(0, 0); c.A != 0 {
+		t.Errorf("Expected a cloud's corner to be clear, got %v", c)
+	}
+	if c := cloud.RGBAAt(textureSize/2, textureSize/2); c.A == 0 {
+		t.Error("Expected a cloud's middle to show")
+	}
+
+	planet := planetImage(rand.New(rand.NewSource(textureSeed)))
+	if c := planet.RGBAAt(0, 0); c.A != 0 {
+		t.Errorf("Expected nothing outside the planet, got %v", c)
+	}
+	lit, dark := planet.RGBAAt(textureSize/3, textureSize/3), planet.RGBAAt(textureSize*3/4, textureSize*3/4)
+	if lit.A != 255 || lit.R <= dark.R {
+		t.Errorf("Expected the top left lit brighter than the bottom right, got %v and %v", lit, dark)
+	}
+}
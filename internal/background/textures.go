@@ -0,0 +1,165 @@
+package background
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/EchoSingh/space-shooter/internal/render"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// textureSize is the width and height of each generated texture
+const textureSize = 64
+
+// Number of cloud and planet textures to pick from
+const (
+	cloudVariants  = 3
+	planetVariants = 2
+)
+
+// textureSeed lays out the textures. They are the same in every run;
+// only where they go and how they are tinted changes with the seed.
+const textureSeed = 1
+
+// textures holds the cloud and planet images, in greys so they can be
+// tinted to any theme
+type textures struct {
+	clouds  []*ebiten.Image
+	planets []*ebiten.Image
+}
+
+// shared is created on first draw, once a graphics context exists
+var shared *textures
+
+// sharedTextures returns the textures, generating them and copying them
+// into the shared atlas on first use
+func sharedTextures() *textures {
+	if shared == nil {
+		rng := rand.New(rand.NewSource(textureSeed))
+		atlas := render.SharedAtlas()
+		shared = &textures{}
+		for i := 0; i < cloudVariants; i++ {
+			shared.clouds = append(shared.clouds, upload(atlas, fmt.Sprintf("background/cloud/%d", i), cloudImage(rng)))
+		}
+		for i := 0; i < planetVariants; i++ {
+			shared.planets = append(shared.planets, upload(atlas, fmt.Sprintf("background/planet/%d", i), planetImage(rng)))
+		}
+	}
+	return shared
+}
+
+// upload copies img into atlas under name, or gives it an image of its
+// own if the atlas has no room
+func upload(atlas *render.Atlas, name string, img image.Image) *ebiten.Image {
+	if region, ok := atlas.Region(name); ok {
+		return region
+	}
+	region, err := atlas.Add(name, img)
+	if err != nil {
+		return ebiten.NewImageFromImage(img)
+	}
+	return region
+}
+
+// noise is smooth value noise over a wrapping grid of random values
+type noise struct {
+	size   int
+	values []float64
+}
+
+func newNoise(rng *rand.Rand, size int) *noise {
+	n := &noise{size: size, values: make([]float64, size*size)}
+	for i := range n.values {
+		n.values[i] = rng.Float64()
+	}
+	return n
+}
+
+// at returns the noise at x, y in grid cells, between 0 and 1
+func (n *noise) at(x, y float64) float64 {
+	x0, y0 := math.Floor(x), math.Floor(y)
+	fx, fy := smoothstep(x-x0), smoothstep(y-y0)
+	value := func(i, j int) float64 {
+		i, j = (i%n.size+n.size)%n.size, (j%n.size+n.size)%n.size
+		return n.values[j*n.size+i]
+	}
+	i, j := int(x0), int(y0)
+	top := lerp(value(i, j), value(i+1, j), fx)
+	bottom := lerp(value(i, j+1), value(i+1, j+1), fx)
+	return lerp(top, bottom, fy)
+}
+
+// fractal adds octaves of noise, each twice as fine and half as strong
+func (n *noise) fractal(x, y float64, octaves int) float64 {
+	sum, weight, total := 0.0, 1.0, 0.0
+	for o := 0; o < octaves; o++ {
+		sum += n.at(x, y) * weight
+		total += weight
+		x, y, weight = x*2, y*2, weight/2
+	}
+	return sum / total
+}
+
+// cloudImage generates a soft, lumpy cloud fading out towards its edge
+func cloudImage(rng *rand.Rand) *image.RGBA {
+	n := newNoise(rng, 8)
+	img := image.NewRGBA(image.Rect(0, 0, textureSize, textureSize))
+	for y := 0; y < textureSize; y++ {
+		for x := 0; x < textureSize; x++ {
+			dx, dy := unit(x), unit(y)
+			falloff := 1 - smoothstep(math.Hypot(dx, dy))
+			density := n.fractal(float64(x)/8, float64(y)/8, 3)
+			a := falloff * density * 1.5
+			img.SetRGBA(x, y, shade(1, a))
+		}
+	}
+	return img
+}
+
+// planetImage generates a banded sphere lit from the top left
+func planetImage(rng *rand.Rand) *image.RGBA {
+	n := newNoise(rng, 8)
+	bands := 3 + rng.Float64()*5
+	img := image.NewRGBA(image.Rect(0, 0, textureSize, textureSize))
+	for y := 0; y < textureSize; y++ {
+		for x := 0; x < textureSize; x++ {
+			dx, dy := unit(x), unit(y)
+			d := math.Hypot(dx, dy)
+			if d >= 1 {
+				continue
+			}
+			// Light falls on the sphere's surface, whose normal is
+			// (dx, dy, dz)
+			dz := math.Sqrt(1 - d*d)
+			light := math.Max(0, (-dx-dy+dz*1.2)/math.Sqrt(3.44))
+			band := 0.8 + 0.2*math.Sin((dy+n.at(float64(x)/8, float64(y)/8)*0.3)*bands*math.Pi)
+			edge := math.Min(1, (1-d)*textureSize/2) // antialias the rim
+			img.SetRGBA(x, y, shade((0.15+0.85*light)*band, edge))
+		}
+	}
+	return img
+}
+
+// shade returns a grey of brightness v at alpha a, premultiplied
+func shade(v, a float64) color.RGBA {
+	a = math.Max(0, math.Min(1, a))
+	c := uint8(math.Max(0, math.Min(1, v)) * a * 255)
+	return color.RGBA{R: c, G: c, B: c, A: uint8(a * 255)}
+}
+
+// unit maps a pixel column or row to -1 to 1 across the texture
+func unit(p int) float64 {
+	return (float64(p)+0.5)/textureSize*2 - 1
+}
+
+func smoothstep(t float64) float64 {
+	t = math.Max(0, math.Min(1, t))
+	return t * t * (3 - 2*t)
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
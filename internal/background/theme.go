@@ -0,0 +1,88 @@
+package background
+
+import (
+	"image/color"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+)
+
+// Theme sets what a background is made of: the sky colour, how many of
+// each kind of scenery there are and the colours they are tinted from
+type Theme struct {
+	Name         string
+	Sky          color.RGBA
+	Stars        int
+	NearStars    int
+	StarColor    color.NRGBA
+	Clouds       int
+	CloudColors  []color.NRGBA
+	Planets      int
+	PlanetColors []color.NRGBA
+	Debris       int
+	DebrisColor  color.NRGBA
+}
+
+var themes = map[string]Theme{
+	config.ThemeDeepSpace: {
+		Name:      config.ThemeDeepSpace,
+		Sky:       color.RGBA{R: 10, G: 10, B: 20, A: 255},
+		Stars:     80,
+		NearStars: 30,
+		StarColor: color.NRGBA{R: 200, G: 200, B: 200, A: 255},
+		Clouds:    2,
+		CloudColors: []color.NRGBA{
+			{R: 60, G: 70, B: 140, A: 70},
+		},
+		Planets: 1,
+		PlanetColors: []color.NRGBA{
+			{R: 90, G: 120, B: 170, A: 255},
+			{R: 170, G: 110, B: 80, A: 255},
+		},
+		Debris:      4,
+		DebrisColor: color.NRGBA{R: 110, G: 110, B: 120, A: 255},
+	},
+	config.ThemeNebula: {
+		Name:      config.ThemeNebula,
+		Sky:       color.RGBA{R: 18, G: 8, B: 30, A: 255},
+		Stars:     60,
+		NearStars: 20,
+		StarColor: color.NRGBA{R: 230, G: 210, B: 240, A: 255},
+		Clouds:    8,
+		CloudColors: []color.NRGBA{
+			{R: 170, G: 60, B: 160, A: 110},
+			{R: 90, G: 60, B: 190, A: 110},
+			{R: 50, G: 130, B: 180, A: 90},
+		},
+		Planets: 1,
+		PlanetColors: []color.NRGBA{
+			{R: 150, G: 100, B: 190, A: 255},
+		},
+	},
+	config.ThemeAsteroidField: {
+		Name:      config.ThemeAsteroidField,
+		Sky:       color.RGBA{R: 12, G: 10, B: 10, A: 255},
+		Stars:     60,
+		NearStars: 20,
+		StarColor: color.NRGBA{R: 220, G: 200, B: 180, A: 255},
+		Clouds:    2,
+		CloudColors: []color.NRGBA{
+			{R: 120, G: 80, B: 50, A: 60},
+		},
+		Planets: 2,
+		PlanetColors: []color.NRGBA{
+			{R: 180, G: 140, B: 100, A: 255},
+			{R: 120, G: 100, B: 90, A: 255},
+		},
+		Debris:      24,
+		DebrisColor: color.NRGBA{R: 130, G: 110, B: 95, A: 255},
+	},
+}
+
+// ThemeFor returns the named theme, or deep space for a name it doesn't
+// know
+func ThemeFor(name string) Theme {
+	if t, ok := themes[name]; ok {
+		return t
+	}
+	return themes[config.ThemeDeepSpace]
+}
@@ -0,0 +1,847 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Enemy type names recognised in the enemies section
+const (
+	EnemyBasic   = "basic"
+	EnemyFast    = "fast"
+	EnemyTank    = "tank"
+	EnemyShooter = "shooter"
+)
+
+// Enemy fire pattern names
+const (
+	FireNone   = ""
+	FireAimed  = "aimed"
+	FireDown   = "down"
+	FireRadial = "radial"
+)
+
+// Enemy movement names. An empty movement keeps the enemy type's own.
+const (
+	MoveDefault  = ""
+	MoveStraight = "straight"
+	MoveSine     = "sine"
+	MoveZigZag   = "zigzag"
+	MoveSeek     = "seek"
+	MoveStrafe   = "strafe"
+	MoveOrbit    = "orbit"
+	MoveDive     = "dive"
+	MoveHover    = "hover"
+)
+
+// Player projectile type names
+const (
+	ProjectileNormal  = "normal"
+	ProjectileSpread  = "spread"
+	ProjectileMissile = "missile"
+	ProjectileLaser   = "laser"
+)
+
+// Power-up names used in enemy drop tables. The weapon power-ups share
+// their names with the projectile types they swap to.
+const (
+	PowerUpHeal      = "heal"
+	PowerUpShield    = "shield"
+	PowerUpRapidFire = "rapid_fire"
+	PowerUpSpread    = ProjectileSpread
+	PowerUpMissile   = ProjectileMissile
+	PowerUpLaser     = ProjectileLaser
+	PowerUpScore     = "score"
+)
+
+// Damage type names used in defence resistances
+const (
+	DamageKinetic   = "kinetic"
+	DamageEnergy    = "energy"
+	DamageExplosive = "explosive"
+)
+
+// Background theme names, for the background section and levels
+const (
+	ThemeDeepSpace     = "deep_space"
+	ThemeNebula        = "nebula"
+	ThemeAsteroidField = "asteroid_field"
+)
+
+// Config holds all tunable game settings
+type Config struct {
+	Game       GameConfig       `yaml:"game"`
+	Player     PlayerConfig     `yaml:"player"`
+	Enemies    EnemiesConfig    `yaml:"enemies"`
+	Bosses     []BossConfig     `yaml:"bosses"`
+	PowerUps   PowerUpsConfig   `yaml:"powerups"`
+	Difficulty DifficultyConfig `yaml:"difficulty"`
+	Scoring    ScoringConfig    `yaml:"scoring"`
+	Particles  ParticlesConfig  `yaml:"particles"`
+	Background BackgroundConfig `yaml:"background"`
+	PostFX     PostFXConfig     `yaml:"postfx"`
+}
+
+// GameConfig holds window and timing settings
+type GameConfig struct {
+	Title      string `yaml:"title"`
+	Width      int    `yaml:"width"`
+	Height     int    `yaml:"height"`
+	FPS        int    `yaml:"fps"`
+	HighScores int    `yaml:"high_scores"`
+}
+
+// PlayerConfig holds player ship tuning.
+// Projectile picks the ship's weapon; each special weapon has its own settings.
+// The ship respawns until its Lives run out, invulnerable for
+// Invulnerability seconds, and after being rammed for HitInvulnerability
+// seconds. An extra life is awarded at each ExtraLives score, up to MaxLives.
+type PlayerConfig struct {
+	Speed              float64       `yaml:"speed"`
+	Health             int           `yaml:"health"`
+	Lives              int           `yaml:"lives"`
+	MaxLives           int           `yaml:"max_lives"`
+	ExtraLives         []int         `yaml:"extra_lives"`
+	Invulnerability    float64       `yaml:"invulnerability"`
+	HitInvulnerability float64       `yaml:"hit_invulnerability"`
+	FireRate           float64       `yaml:"fire_rate"`
+	BulletSpeed        float64       `yaml:"bullet_speed"`
+	BulletDamage       int           `yaml:"bullet_damage"`
+	Projectile         string        `yaml:"projectile"`
+	Spread             SpreadConfig  `yaml:"spread"`
+	Missile            MissileConfig `yaml:"missile"`
+	Laser              LaserConfig   `yaml:"laser"`
+}
+
+// SpreadConfig shapes the spread shot's fan of bullets.
+// Angle is the width of the fan in degrees.
+type SpreadConfig struct {
+	Count int     `yaml:"count"`
+	Angle float64 `yaml:"angle"`
+}
+
+// MissileConfig tunes homing missiles. TurnRate is in radians per second.
+type MissileConfig struct {
+	Speed    float64 `yaml:"speed"`
+	TurnRate float64 `yaml:"turn_rate"`
+	FireRate float64 `yaml:"fire_rate"`
+	Damage   int     `yaml:"damage"`
+}
+
+// LaserConfig tunes the continuous laser beam
+type LaserConfig struct {
+	DamagePerSecond float64 `yaml:"damage_per_second"`
+	Width           float64 `yaml:"width"`
+}
+
+// EnemiesConfig holds enemy spawning and per-type stats.
+// Random spawns stop while MaxEnemies are in play, and only ColumnChance
+// of the spawns that would enter within SafeColumn pixels of the
+// player's x position are let through; the rest are moved aside.
+type EnemiesConfig struct {
+	SpawnInterval float64           `yaml:"spawn_interval"`
+	MaxEnemies    int               `yaml:"max_enemies"`
+	SafeColumn    float64           `yaml:"safe_column"`
+	ColumnChance  float64           `yaml:"column_chance"`
+	Types         []EnemyTypeConfig `yaml:"types"`
+}
+
+// EnemyTypeConfig holds the stats of a single enemy type.
+// Random spawns pick types by SpawnWeight at the start, shifting toward
+// LateSpawnWeight as difficulty reaches its maximum. Movement overrides
+// the type's usual flight path. Enemies with a fire pattern shoot every
+// FireRate seconds. On death an enemy drops a power-up with probability
+// DropChance, picked from Drops by weight.
+type EnemyTypeConfig struct {
+	Name            string             `yaml:"name"`
+	Health          int                `yaml:"health"`
+	Speed           float64            `yaml:"speed"`
+	Score           int                `yaml:"score"`
+	SpawnWeight     float64            `yaml:"spawn_weight"`
+	LateSpawnWeight float64            `yaml:"late_spawn_weight"`
+	Movement        string             `yaml:"movement"`
+	FirePattern     string             `yaml:"fire_pattern"`
+	FireRate        float64            `yaml:"fire_rate"`
+	BulletSpeed     float64            `yaml:"bullet_speed"`
+	BulletDamage    int                `yaml:"bullet_damage"`
+	BurstCount      int                `yaml:"burst_count"`
+	DropChance      float64            `yaml:"drop_chance"`
+	Drops           map[string]float64 `yaml:"drops"`
+	Defence         DefenceConfig      `yaml:"defence"`
+}
+
+// DefenceConfig gives an enemy or boss its defensive profile. Each hit is
+// scaled down by the resistance to its damage type, loses Armour points,
+// but never all of them, then drains the shield before reaching health.
+// A laser loses Armour points from each second of its damage instead.
+// Negative resistances are weaknesses. The shield recharges ShieldRegen
+// points a second once ShieldDelay seconds pass without a hit.
+type DefenceConfig struct {
+	Shield      float64            `yaml:"shield"`
+	ShieldRegen float64            `yaml:"shield_regen"`
+	ShieldDelay float64            `yaml:"shield_delay"`
+	Armour      int                `yaml:"armour"`
+	Resistances map[string]float64 `yaml:"resistances"`
+}
+
+// BossConfig describes a boss. Bullets and lasers damage it through its
+// zones, and it switches to the next phase as its health drops.
+type BossConfig struct {
+	Name    string            `yaml:"name"`
+	Health  int               `yaml:"health"`
+	Score   int               `yaml:"score"`
+	Speed   float64           `yaml:"speed"`
+	Width   float64           `yaml:"width"`
+	Height  float64           `yaml:"height"`
+	Zones   []BossZoneConfig  `yaml:"zones"`
+	Phases  []BossPhaseConfig `yaml:"phases"`
+	Defence DefenceConfig     `yaml:"defence"`
+}
+
+// BossZoneConfig is a hit zone centred at X, Y from the boss's centre.
+// Damage taken through the zone is scaled by Multiplier.
+type BossZoneConfig struct {
+	Name       string  `yaml:"name"`
+	X          float64 `yaml:"x"`
+	Y          float64 `yaml:"y"`
+	Width      float64 `yaml:"width"`
+	Height     float64 `yaml:"height"`
+	Multiplier float64 `yaml:"multiplier"`
+}
+
+// BossPhaseConfig is a boss phase, which starts once the boss's health
+// fraction falls to Threshold. The first phase's threshold is 1.
+type BossPhaseConfig struct {
+	Threshold    float64 `yaml:"threshold"`
+	Movement     string  `yaml:"movement"`
+	FirePattern  string  `yaml:"fire_pattern"`
+	FireRate     float64 `yaml:"fire_rate"`
+	BulletSpeed  float64 `yaml:"bullet_speed"`
+	BulletDamage int     `yaml:"bullet_damage"`
+	BurstCount   int     `yaml:"burst_count"`
+}
+
+// PowerUpsConfig tunes dropped power-ups.
+// Timed effects last Duration seconds; RapidFire multiplies the fire rate.
+type PowerUpsConfig struct {
+	FallSpeed       float64 `yaml:"fall_speed"`
+	Duration        float64 `yaml:"duration"`
+	HealAmount      int     `yaml:"heal_amount"`
+	RapidFire       float64 `yaml:"rapid_fire"`
+	ScoreMultiplier int     `yaml:"score_multiplier"`
+}
+
+// DifficultyConfig controls how the game ramps up over time.
+// The multiplier climbs by IncreaseRate a second, then Adaptivity moves
+// it up to that fraction higher or lower by how well the player is doing
+// over the last Window seconds: their health, hit rate against
+// TargetHitRate, how long enemies survive against TargetKillTime, and
+// the share of health lost against DamageTolerance. The multiplier
+// settles on its target over Smoothing seconds. At MaxMultiplier enemies
+// move MaxEnemySpeed times faster and fire MaxFireRate times as often.
+type DifficultyConfig struct {
+	IncreaseRate    float64 `yaml:"increase_rate"`
+	MaxMultiplier   float64 `yaml:"max_multiplier"`
+	SpawnRateMin    float64 `yaml:"spawn_rate_min"`
+	Adaptivity      float64 `yaml:"adaptivity"`
+	Smoothing       float64 `yaml:"smoothing"`
+	Window          float64 `yaml:"window"`
+	TargetHitRate   float64 `yaml:"target_hit_rate"`
+	TargetKillTime  float64 `yaml:"target_kill_time"`
+	DamageTolerance float64 `yaml:"damage_tolerance"`
+	MaxEnemySpeed   float64 `yaml:"max_enemy_speed"`
+	MaxFireRate     float64 `yaml:"max_fire_rate"`
+}
+
+// ScoringConfig tunes combos and bonuses.
+// Kills less than ComboWindow seconds apart chain into a combo, and every
+// ComboStep kills in the chain raise the kill multiplier by one, up to
+// MaxMultiplier. Clearing a level wave without losing health earns
+// NoDamageBonus, and hitting with at least AccuracyThreshold of the
+// wave's shots earns that share of AccuracyBonus. An enemy bullet that
+// passes within GrazeRadius pixels of the ship earns GrazePoints.
+// Score popups float for PopupTime seconds.
+type ScoringConfig struct {
+	ComboWindow       float64 `yaml:"combo_window"`
+	ComboStep         int     `yaml:"combo_step"`
+	MaxMultiplier     int     `yaml:"max_multiplier"`
+	NoDamageBonus     int     `yaml:"no_damage_bonus"`
+	AccuracyBonus     int     `yaml:"accuracy_bonus"`
+	AccuracyThreshold float64 `yaml:"accuracy_threshold"`
+	GrazeRadius       float64 `yaml:"graze_radius"`
+	GrazePoints       int     `yaml:"graze_points"`
+	PopupTime         float64 `yaml:"popup_time"`
+}
+
+// ParticlesConfig controls particle effects.
+// TrailFrequency is the number of trail particles emitted per shot.
+type ParticlesConfig struct {
+	ExplosionCount int `yaml:"explosion_count"`
+	TrailFrequency int `yaml:"trail_frequency"`
+	MaxParticles   int `yaml:"max_particles"`
+}
+
+// BackgroundConfig controls the scrolling background.
+// Theme is used when the level doesn't pick one. The nearest layer
+// scrolls at ScrollSpeed pixels per second, and layers further back
+// more slowly. Progress through the level speeds everything up, by
+// ProgressBoost times more at the end; without a level, progress builds
+// up over EndlessRamp seconds.
+type BackgroundConfig struct {
+	Theme         string  `yaml:"theme"`
+	ScrollSpeed   float64 `yaml:"scroll_speed"`
+	ProgressBoost float64 `yaml:"progress_boost"`
+	EndlessRamp   float64 `yaml:"endless_ramp"`
+}
+
+// PostFXConfig turns each post-processing effect on or off and tunes it.
+// Bloom makes anything brighter than BloomThreshold glow by
+// BloomIntensity. Taking damage flashes the screen and splits its colours
+// by up to AberrationAmount pixels, fading over FlashTime seconds. CRT
+// darkens every other line by Scanlines, and Vignette darkens the corners
+// by VignetteStrength.
+type PostFXConfig struct {
+	Bloom            bool    `yaml:"bloom"`
+	BloomThreshold   float64 `yaml:"bloom_threshold"`
+	BloomIntensity   float64 `yaml:"bloom_intensity"`
+	Aberration       bool    `yaml:"aberration"`
+	AberrationAmount float64 `yaml:"aberration_amount"`
+	Flash            bool    `yaml:"flash"`
+	FlashTime        float64 `yaml:"flash_time"`
+	CRT              bool    `yaml:"crt"`
+	Scanlines        float64 `yaml:"scanlines"`
+	Vignette         bool    `yaml:"vignette"`
+	VignetteStrength float64 `yaml:"vignette_strength"`
+}
+
+// Default returns the built-in configuration, matching configs/game.yaml
+func Default() *Config {
+	return &Config{
+		Game: GameConfig{
+			Title:      "Space Shooter",
+			Width:      800,
+			Height:     600,
+			FPS:        60,
+			HighScores: 10,
+		},
+		Player: PlayerConfig{
+			Speed:              300,
+			Health:             100,
+			Lives:              3,
+			MaxLives:           5,
+			ExtraLives:         []int{1000, 3000, 6000, 10000},
+			Invulnerability:    2.5,
+			HitInvulnerability: 0.5,
+			FireRate:           0.15,
+			BulletSpeed:        500,
+			BulletDamage:       10,
+			Projectile:         ProjectileNormal,
+			Spread: SpreadConfig{
+				Count: 5,
+				Angle: 40,
+			},
+			Missile: MissileConfig{
+				Speed:    280,
+				TurnRate: 4,
+				FireRate: 0.4,
+				Damage:   20,
+			},
+			Laser: LaserConfig{
+				DamagePerSecond: 60,
+				Width:           6,
+			},
+		},
+		Enemies: EnemiesConfig{
+			SpawnInterval: 2.0,
+			MaxEnemies:    25,
+			SafeColumn:    60,
+			ColumnChance:  0.2,
+			Types:         defaultEnemyTypes(),
+		},
+		Bosses: defaultBosses(),
+		PowerUps: PowerUpsConfig{
+			FallSpeed:       80,
+			Duration:        8,
+			HealAmount:      30,
+			RapidFire:       2,
+			ScoreMultiplier: 2,
+		},
+		Difficulty: DifficultyConfig{
+			IncreaseRate:    0.033,
+			MaxMultiplier:   5.0,
+			SpawnRateMin:    0.5,
+			Adaptivity:      0.5,
+			Smoothing:       4,
+			Window:          10,
+			TargetHitRate:   0.4,
+			TargetKillTime:  4,
+			DamageTolerance: 0.3,
+			MaxEnemySpeed:   1.5,
+			MaxFireRate:     2,
+		},
+		Scoring: ScoringConfig{
+			ComboWindow:       2,
+			ComboStep:         5,
+			MaxMultiplier:     8,
+			NoDamageBonus:     250,
+			AccuracyBonus:     300,
+			AccuracyThreshold: 0.5,
+			GrazeRadius:       20,
+			GrazePoints:       5,
+			PopupTime:         1,
+		},
+		Particles: ParticlesConfig{
+			ExplosionCount: 20,
+			TrailFrequency: 3,
+			MaxParticles:   500,
+		},
+		Background: BackgroundConfig{
+			Theme:         ThemeDeepSpace,
+			ScrollSpeed:   60,
+			ProgressBoost: 1.5,
+			EndlessRamp:   120,
+		},
+		PostFX: PostFXConfig{
+			Bloom:            true,
+			BloomThreshold:   0.6,
+			BloomIntensity:   0.8,
+			Aberration:       true,
+			AberrationAmount: 6,
+			Flash:            true,
+			FlashTime:        0.3,
+			CRT:              false,
+			Scanlines:        0.3,
+			Vignette:         true,
+			VignetteStrength: 0.35,
+		},
+	}
+}
+
+func defaultEnemyTypes() []EnemyTypeConfig {
+	return []EnemyTypeConfig{
+		{
+			Name: EnemyBasic, Health: 20, Speed: 100, Score: 10, SpawnWeight: 40, LateSpawnWeight: 15,
+			DropChance: 0.08, Drops: map[string]float64{PowerUpHeal: 3, PowerUpRapidFire: 2, PowerUpScore: 1},
+		},
+		{
+			Name: EnemyFast, Health: 10, Speed: 200, Score: 15, SpawnWeight: 30, LateSpawnWeight: 30,
+			DropChance: 0.1, Drops: map[string]float64{PowerUpShield: 2, PowerUpRapidFire: 2, PowerUpSpread: 1},
+		},
+		{
+			Name: EnemyTank, Health: 50, Speed: 50, Score: 25, SpawnWeight: 20, LateSpawnWeight: 30,
+			DropChance: 0.3, Drops: map[string]float64{PowerUpHeal: 2, PowerUpShield: 2, PowerUpMissile: 1, PowerUpLaser: 1},
+			Defence: DefenceConfig{Armour: 3, Resistances: map[string]float64{DamageKinetic: 0.25, DamageExplosive: -0.5}},
+		},
+		{
+			Name: EnemyShooter, Health: 30, Speed: 80, Score: 20, SpawnWeight: 10, LateSpawnWeight: 25,
+			FirePattern: FireAimed, FireRate: 1.5, BulletSpeed: 200, BulletDamage: 10, BurstCount: 1,
+			DropChance: 0.2, Drops: map[string]float64{PowerUpShield: 1, PowerUpSpread: 2, PowerUpMissile: 1, PowerUpScore: 1},
+			Defence: DefenceConfig{Shield: 15, ShieldRegen: 5, ShieldDelay: 2, Resistances: map[string]float64{DamageEnergy: -0.5}},
+		},
+	}
+}
+
+func defaultBosses() []BossConfig {
+	return []BossConfig{
+		{
+			Name: "dreadnought", Health: 800, Score: 500, Speed: 60, Width: 180, Height: 80,
+			Defence: DefenceConfig{
+				Shield: 150, ShieldRegen: 15, ShieldDelay: 4, Armour: 2,
+				Resistances: map[string]float64{DamageEnergy: 0.3},
+			},
+			Zones: []BossZoneConfig{
+				{Name: "hull", Y: -10, Width: 120, Height: 50, Multiplier: 1},
+				{Name: "left wing", X: -70, Width: 40, Height: 30, Multiplier: 0.5},
+				{Name: "right wing", X: 70, Width: 40, Height: 30, Multiplier: 0.5},
+				{Name: "core", Y: 25, Width: 30, Height: 20, Multiplier: 2},
+			},
+			Phases: []BossPhaseConfig{
+				{Threshold: 1, Movement: MoveHover, FirePattern: FireAimed, FireRate: 1.2, BulletSpeed: 220, BulletDamage: 10, BurstCount: 1},
+				{Threshold: 0.6, Movement: MoveStrafe, FirePattern: FireRadial, FireRate: 1.5, BulletSpeed: 180, BulletDamage: 8, BurstCount: 12},
+				{Threshold: 0.25, Movement: MoveHover, FirePattern: FireAimed, FireRate: 0.4, BulletSpeed: 300, BulletDamage: 10, BurstCount: 1},
+			},
+		},
+	}
+}
+
+// Load reads and validates a configuration file.
+// Settings missing from the file keep their default values.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Parse decodes and validates YAML configuration data
+func Parse(data []byte) (*Config, error) {
+	cfg := Default()
+	cfg.Enemies.Types = nil
+	cfg.Bosses = nil
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing config: %w", err)
+	}
+
+	cfg.Enemies.fillDefaults()
+	cfg.fillDefaultBosses()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// UnmarshalYAML starts each listed enemy type from its built-in stats,
+// so a file only needs to name the fields it changes
+func (t *EnemyTypeConfig) UnmarshalYAML(node *yaml.Node) error {
+	type plain EnemyTypeConfig
+
+	var named struct {
+		Name string `yaml:"name"`
+	}
+	if err := node.Decode(&named); err != nil {
+		return err
+	}
+
+	for _, def := range defaultEnemyTypes() {
+		if def.Name == named.Name {
+			*t = def
+		}
+	}
+	return node.Decode((*plain)(t))
+}
+
+// fillDefaults appends default entries for any enemy types the file leaves out
+func (e *EnemiesConfig) fillDefaults() {
+	for _, def := range defaultEnemyTypes() {
+		if _, ok := e.EnemyType(def.Name); !ok {
+			e.Types = append(e.Types, def)
+		}
+	}
+}
+
+// fillDefaultBosses appends the built-in bosses the file leaves out
+func (c *Config) fillDefaultBosses() {
+	for _, def := range defaultBosses() {
+		if _, ok := c.Boss(def.Name); !ok {
+			c.Bosses = append(c.Bosses, def)
+		}
+	}
+}
+
+// Boss returns the named boss
+func (c *Config) Boss(name string) (BossConfig, bool) {
+	for _, b := range c.Bosses {
+		if b.Name == name {
+			return b, true
+		}
+	}
+	return BossConfig{}, false
+}
+
+// Validate checks that all values are usable
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Game.Width > 0, "game.width must be positive, got %d", c.Game.Width)
+	check(c.Game.Height > 0, "game.height must be positive, got %d", c.Game.Height)
+	check(c.Game.FPS > 0, "game.fps must be positive, got %d", c.Game.FPS)
+	check(c.Game.HighScores > 0, "game.high_scores must be positive, got %d", c.Game.HighScores)
+
+	check(c.Player.Speed > 0, "player.speed must be positive, got %g", c.Player.Speed)
+	check(c.Player.Health > 0, "player.health must be positive, got %d", c.Player.Health)
+	check(c.Player.Lives > 0, "player.lives must be positive, got %d", c.Player.Lives)
+	check(c.Player.MaxLives >= c.Player.Lives, "player.max_lives must be at least player.lives, got %d", c.Player.MaxLives)
+	for i, score := range c.Player.ExtraLives {
+		check(score > 0 && (i == 0 || score > c.Player.ExtraLives[i-1]),
+			"player.extra_lives[%d] must be positive and above the previous score, got %d", i, score)
+	}
+	check(c.Player.Invulnerability >= 0, "player.invulnerability must not be negative, got %g", c.Player.Invulnerability)
+	check(c.Player.HitInvulnerability >= 0, "player.hit_invulnerability must not be negative, got %g", c.Player.HitInvulnerability)
+	check(c.Player.FireRate > 0, "player.fire_rate must be positive, got %g", c.Player.FireRate)
+	check(c.Player.BulletSpeed > 0, "player.bullet_speed must be positive, got %g", c.Player.BulletSpeed)
+	check(c.Player.BulletDamage > 0, "player.bullet_damage must be positive, got %d", c.Player.BulletDamage)
+	check(isProjectile(c.Player.Projectile), "player.projectile %q is not one of %s", c.Player.Projectile, strings.Join(ProjectileNames(), ", "))
+	check(c.Player.Spread.Count > 0, "player.spread.count must be positive, got %d", c.Player.Spread.Count)
+	check(c.Player.Spread.Angle >= 0 && c.Player.Spread.Angle < 360, "player.spread.angle must be between 0 and 360, got %g", c.Player.Spread.Angle)
+	check(c.Player.Missile.Speed > 0, "player.missile.speed must be positive, got %g", c.Player.Missile.Speed)
+	check(c.Player.Missile.TurnRate >= 0, "player.missile.turn_rate must not be negative, got %g", c.Player.Missile.TurnRate)
+	check(c.Player.Missile.FireRate > 0, "player.missile.fire_rate must be positive, got %g", c.Player.Missile.FireRate)
+	check(c.Player.Missile.Damage > 0, "player.missile.damage must be positive, got %d", c.Player.Missile.Damage)
+	check(c.Player.Laser.DamagePerSecond > 0, "player.laser.damage_per_second must be positive, got %g", c.Player.Laser.DamagePerSecond)
+	check(c.Player.Laser.Width > 0, "player.laser.width must be positive, got %g", c.Player.Laser.Width)
+
+	check(c.Enemies.SpawnInterval > 0, "enemies.spawn_interval must be positive, got %g", c.Enemies.SpawnInterval)
+	check(c.Enemies.MaxEnemies > 0, "enemies.max_enemies must be positive, got %d", c.Enemies.MaxEnemies)
+	check(c.Enemies.SafeColumn >= 0, "enemies.safe_column must not be negative, got %g", c.Enemies.SafeColumn)
+	check(c.Enemies.ColumnChance >= 0 && c.Enemies.ColumnChance <= 1, "enemies.column_chance must be between 0 and 1, got %g", c.Enemies.ColumnChance)
+	seen := make(map[string]bool)
+	var weight, lateWeight float64
+	for i, t := range c.Enemies.Types {
+		field := fmt.Sprintf("enemies.types[%d]", i)
+		check(isEnemyType(t.Name), "%s.name %q is not one of %s", field, t.Name, strings.Join(EnemyTypeNames(), ", "))
+		check(!seen[t.Name], "%s.name %q is listed more than once", field, t.Name)
+		seen[t.Name] = true
+		check(t.Health > 0, "%s.health must be positive, got %d", field, t.Health)
+		check(t.Speed > 0, "%s.speed must be positive, got %g", field, t.Speed)
+		check(t.Score >= 0, "%s.score must not be negative, got %d", field, t.Score)
+		check(t.SpawnWeight >= 0, "%s.spawn_weight must not be negative, got %g", field, t.SpawnWeight)
+		check(t.LateSpawnWeight >= 0, "%s.late_spawn_weight must not be negative, got %g", field, t.LateSpawnWeight)
+		weight += t.SpawnWeight
+		lateWeight += t.LateSpawnWeight
+		check(isMovement(t.Movement), "%s.movement %q is not one of %s", field, t.Movement, strings.Join(MovementNames(), ", "))
+		check(isFirePattern(t.FirePattern), "%s.fire_pattern %q is not one of %s", field, t.FirePattern, strings.Join(FirePatternNames(), ", "))
+		if t.FirePattern != FireNone {
+			check(t.FireRate > 0, "%s.fire_rate must be positive, got %g", field, t.FireRate)
+			check(t.BulletSpeed > 0, "%s.bullet_speed must be positive, got %g", field, t.BulletSpeed)
+			check(t.BulletDamage > 0, "%s.bullet_damage must be positive, got %d", field, t.BulletDamage)
+			check(t.BurstCount > 0, "%s.burst_count must be positive, got %d", field, t.BurstCount)
+		}
+		check(t.DropChance >= 0 && t.DropChance <= 1, "%s.drop_chance must be between 0 and 1, got %g", field, t.DropChance)
+		t.Defence.validate(field+".defence", check)
+		for _, name := range sortedKeys(t.Drops) {
+			check(isPowerUp(name), "%s.drops %q is not one of %s", field, name, strings.Join(PowerUpNames(), ", "))
+			check(t.Drops[name] >= 0, "%s.drops.%s must not be negative, got %g", field, name, t.Drops[name])
+		}
+	}
+	check(weight > 0, "enemies.types spawn_weight must be positive for at least one type")
+	check(lateWeight > 0, "enemies.types late_spawn_weight must be positive for at least one type")
+
+	seenBoss := make(map[string]bool)
+	for i, b := range c.Bosses {
+		field := fmt.Sprintf("bosses[%d]", i)
+		check(b.Name != "", "%s.name must not be empty", field)
+		check(!seenBoss[b.Name], "%s.name %q is listed more than once", field, b.Name)
+		seenBoss[b.Name] = true
+		check(b.Health > 0, "%s.health must be positive, got %d", field, b.Health)
+		check(b.Score >= 0, "%s.score must not be negative, got %d", field, b.Score)
+		check(b.Speed > 0, "%s.speed must be positive, got %g", field, b.Speed)
+		check(b.Width > 0 && b.Height > 0, "%s.width and height must be positive, got %gx%g", field, b.Width, b.Height)
+		b.Defence.validate(field+".defence", check)
+		check(len(b.Zones) > 0, "%s.zones must list at least one zone", field)
+		for j, z := range b.Zones {
+			field := fmt.Sprintf("%s.zones[%d]", field, j)
+			check(z.Width > 0 && z.Height > 0, "%s.width and height must be positive, got %gx%g", field, z.Width, z.Height)
+			check(z.Multiplier > 0, "%s.multiplier must be positive, got %g", field, z.Multiplier)
+		}
+		check(len(b.Phases) > 0, "%s.phases must list at least one phase", field)
+		for j, p := range b.Phases {
+			field := fmt.Sprintf("%s.phases[%d]", field, j)
+			if j == 0 {
+				check(p.Threshold == 1, "%s.threshold must be 1 for the first phase, got %g", field, p.Threshold)
+			} else {
+				check(p.Threshold > 0 && p.Threshold < b.Phases[j-1].Threshold,
+					"%s.threshold must be positive and below the previous phase's, got %g", field, p.Threshold)
+			}
+			check(isMovement(p.Movement), "%s.movement %q is not one of %s", field, p.Movement, strings.Join(MovementNames(), ", "))
+			check(isFirePattern(p.FirePattern), "%s.fire_pattern %q is not one of %s", field, p.FirePattern, strings.Join(FirePatternNames(), ", "))
+			if p.FirePattern != FireNone {
+				check(p.FireRate > 0, "%s.fire_rate must be positive, got %g", field, p.FireRate)
+				check(p.BulletSpeed > 0, "%s.bullet_speed must be positive, got %g", field, p.BulletSpeed)
+				check(p.BulletDamage > 0, "%s.bullet_damage must be positive, got %d", field, p.BulletDamage)
+				check(p.BurstCount > 0, "%s.burst_count must be positive, got %d", field, p.BurstCount)
+			}
+		}
+	}
+
+	check(c.PowerUps.FallSpeed > 0, "powerups.fall_speed must be positive, got %g", c.PowerUps.FallSpeed)
+	check(c.PowerUps.Duration > 0, "powerups.duration must be positive, got %g", c.PowerUps.Duration)
+	check(c.PowerUps.HealAmount > 0, "powerups.heal_amount must be positive, got %d", c.PowerUps.HealAmount)
+	check(c.PowerUps.RapidFire >= 1, "powerups.rapid_fire must be at least 1, got %g", c.PowerUps.RapidFire)
+	check(c.PowerUps.ScoreMultiplier >= 1, "powerups.score_multiplier must be at least 1, got %d", c.PowerUps.ScoreMultiplier)
+
+	check(c.Difficulty.IncreaseRate >= 0, "difficulty.increase_rate must not be negative, got %g", c.Difficulty.IncreaseRate)
+	check(c.Difficulty.MaxMultiplier >= 1, "difficulty.max_multiplier must be at least 1, got %g", c.Difficulty.MaxMultiplier)
+	check(c.Difficulty.SpawnRateMin > 0, "difficulty.spawn_rate_min must be positive, got %g", c.Difficulty.SpawnRateMin)
+	check(c.Difficulty.Adaptivity >= 0 && c.Difficulty.Adaptivity <= 1, "difficulty.adaptivity must be between 0 and 1, got %g", c.Difficulty.Adaptivity)
+	check(c.Difficulty.Smoothing >= 0, "difficulty.smoothing must not be negative, got %g", c.Difficulty.Smoothing)
+	check(c.Difficulty.Window > 0, "difficulty.window must be positive, got %g", c.Difficulty.Window)
+	check(c.Difficulty.TargetHitRate > 0 && c.Difficulty.TargetHitRate <= 1, "difficulty.target_hit_rate must be between 0 and 1, got %g", c.Difficulty.TargetHitRate)
+	check(c.Difficulty.TargetKillTime > 0, "difficulty.target_kill_time must be positive, got %g", c.Difficulty.TargetKillTime)
+	check(c.Difficulty.DamageTolerance > 0 && c.Difficulty.DamageTolerance <= 1, "difficulty.damage_tolerance must be between 0 and 1, got %g", c.Difficulty.DamageTolerance)
+	check(c.Difficulty.MaxEnemySpeed >= 1, "difficulty.max_enemy_speed must be at least 1, got %g", c.Difficulty.MaxEnemySpeed)
+	check(c.Difficulty.MaxFireRate >= 1, "difficulty.max_fire_rate must be at least 1, got %g", c.Difficulty.MaxFireRate)
+
+	check(c.Scoring.ComboWindow > 0, "scoring.combo_window must be positive, got %g", c.Scoring.ComboWindow)
+	check(c.Scoring.ComboStep > 0, "scoring.combo_step must be positive, got %d", c.Scoring.ComboStep)
+	check(c.Scoring.MaxMultiplier >= 1, "scoring.max_multiplier must be at least 1, got %d", c.Scoring.MaxMultiplier)
+	check(c.Scoring.NoDamageBonus >= 0, "scoring.no_damage_bonus must not be negative, got %d", c.Scoring.NoDamageBonus)
+	check(c.Scoring.AccuracyBonus >= 0, "scoring.accuracy_bonus must not be negative, got %d", c.Scoring.AccuracyBonus)
+	check(c.Scoring.AccuracyThreshold >= 0 && c.Scoring.AccuracyThreshold <= 1, "scoring.accuracy_threshold must be between 0 and 1, got %g", c.Scoring.AccuracyThreshold)
+	check(c.Scoring.GrazeRadius >= 0, "scoring.graze_radius must not be negative, got %g", c.Scoring.GrazeRadius)
+	check(c.Scoring.GrazePoints >= 0, "scoring.graze_points must not be negative, got %d", c.Scoring.GrazePoints)
+	check(c.Scoring.PopupTime > 0, "scoring.popup_time must be positive, got %g", c.Scoring.PopupTime)
+
+	check(c.Particles.ExplosionCount >= 0, "particles.explosion_count must not be negative, got %d", c.Particles.ExplosionCount)
+	check(c.Particles.TrailFrequency > 0, "particles.trail_frequency must be positive, got %d", c.Particles.TrailFrequency)
+	check(c.Particles.MaxParticles > 0, "particles.max_particles must be positive, got %d", c.Particles.MaxParticles)
+
+	check(isTheme(c.Background.Theme), "background.theme %q is not one of %s", c.Background.Theme, strings.Join(ThemeNames(), ", "))
+	check(c.Background.ScrollSpeed > 0, "background.scroll_speed must be positive, got %g", c.Background.ScrollSpeed)
+	check(c.Background.ProgressBoost >= 0, "background.progress_boost must not be negative, got %g", c.Background.ProgressBoost)
+	check(c.Background.EndlessRamp > 0, "background.endless_ramp must be positive, got %g", c.Background.EndlessRamp)
+
+	check(c.PostFX.BloomThreshold >= 0 && c.PostFX.BloomThreshold < 1, "postfx.bloom_threshold must be at least 0 and below 1, got %g", c.PostFX.BloomThreshold)
+	check(c.PostFX.BloomIntensity >= 0, "postfx.bloom_intensity must not be negative, got %g", c.PostFX.BloomIntensity)
+	check(c.PostFX.AberrationAmount >= 0, "postfx.aberration_amount must not be negative, got %g", c.PostFX.AberrationAmount)
+	check(c.PostFX.FlashTime > 0, "postfx.flash_time must be positive, got %g", c.PostFX.FlashTime)
+	check(c.PostFX.Scanlines >= 0 && c.PostFX.Scanlines <= 1, "postfx.scanlines must be between 0 and 1, got %g", c.PostFX.Scanlines)
+	check(c.PostFX.VignetteStrength >= 0 && c.PostFX.VignetteStrength <= 1, "postfx.vignette_strength must be between 0 and 1, got %g", c.PostFX.VignetteStrength)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// EnemyTypeNames returns the recognised enemy type names
+func EnemyTypeNames() []string {
+	return []string{EnemyBasic, EnemyFast, EnemyTank, EnemyShooter}
+}
+
+func isEnemyType(name string) bool {
+	for _, n := range EnemyTypeNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// MovementNames returns the recognised enemy movements
+func MovementNames() []string {
+	return []string{MoveStraight, MoveSine, MoveZigZag, MoveSeek, MoveStrafe, MoveOrbit, MoveDive, MoveHover}
+}
+
+func isMovement(name string) bool {
+	if name == MoveDefault {
+		return true
+	}
+	for _, n := range MovementNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectileNames returns the recognised player projectile types
+func ProjectileNames() []string {
+	return []string{ProjectileNormal, ProjectileSpread, ProjectileMissile, ProjectileLaser}
+}
+
+func isProjectile(name string) bool {
+	for _, n := range ProjectileNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (d DefenceConfig) validate(field string, check func(bool, string, ...interface{})) {
+	check(d.Shield >= 0, "%s.shield must not be negative, got %g", field, d.Shield)
+	check(d.ShieldRegen >= 0, "%s.shield_regen must not be negative, got %g", field, d.ShieldRegen)
+	check(d.ShieldDelay >= 0, "%s.shield_delay must not be negative, got %g", field, d.ShieldDelay)
+	check(d.Armour >= 0, "%s.armour must not be negative, got %d", field, d.Armour)
+	for _, name := range sortedKeys(d.Resistances) {
+		check(isDamageType(name), "%s.resistances %q is not one of %s", field, name, strings.Join(DamageTypeNames(), ", "))
+		check(d.Resistances[name] >= -1 && d.Resistances[name] <= 1,
+			"%s.resistances.%s must be between -1 and 1, got %g", field, name, d.Resistances[name])
+	}
+}
+
+// DamageTypeNames returns the recognised damage types
+func DamageTypeNames() []string {
+	return []string{DamageKinetic, DamageEnergy, DamageExplosive}
+}
+
+func isDamageType(name string) bool {
+	for _, n := range DamageTypeNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// PowerUpNames returns the recognised power-ups in drop table order
+func PowerUpNames() []string {
+	return []string{PowerUpHeal, PowerUpShield, PowerUpRapidFire, PowerUpSpread, PowerUpMissile, PowerUpLaser, PowerUpScore}
+}
+
+func isPowerUp(name string) bool {
+	for _, n := range PowerUpNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ThemeNames returns the recognised background themes
+func ThemeNames() []string {
+	return []string{ThemeDeepSpace, ThemeNebula, ThemeAsteroidField}
+}
+
+func isTheme(name string) bool {
+	for _, n := range ThemeNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// FirePatternNames returns the recognised enemy fire patterns
+func FirePatternNames() []string {
+	return []string{FireAimed, FireDown, FireRadial}
+}
+
+func isFirePattern(name string) bool {
+	if name == FireNone {
+		return true
+	}
+	for _, n := range FirePatternNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// EnemyType returns the stats for the named enemy type
+func (e *EnemiesConfig) EnemyType(name string) (EnemyTypeConfig, bool) {
+	for _, t := range e.Types {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return EnemyTypeConfig{}, false
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultIsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Fatalf("Default config should be valid: %v", err)
+	}
+}
+
+func TestLoadGameConfig(t *testing.T) {
+	cfg, err := Load("../../configs/game.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load configs/game.yaml: %v", err)
+	}
+
+	if cfg.Player.Speed != 300 {
+		t.Errorf("Expected player speed 300, got %g", cfg.Player.Speed)
+	}
+
+	tank, ok := cfg.Enemies.EnemyType(EnemyTank)
+	if !ok {
+		t.Fatal("Expected tank enemy type")
+	}
+	if tank.Health != 50 || tank.SpawnWeight != 20 {
+		t.Errorf("Unexpected tank stats: %+v", tank)
+	}
+}
+
+func TestParseKeepsDefaults(t *testing.T) {
+	cfg, err := Parse([]byte(`
+player:
+  speed: 450
+enemies:
+  types:
+    - name: "fast"
+      speed: 250
+`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if cfg.Player.Speed != 450 {
+		t.Errorf("Expected player speed 450, got %g", cfg.Player.Speed)
+	}
+	if cfg.Player.Health != Default().Player.Health {
+		t.Errorf("Expected default player health, got %d", cfg.Player.Health)
+	}
+
+	fast, _ := cfg.Enemies.EnemyType(EnemyFast)
+	if fast.Speed != 250 || fast.Health != 10 {
+		t.Errorf("Expected fast enemy to keep default health, got %+v", fast)
+	}
+
+	if len(cfg.Enemies.Types) != len(EnemyTypeNames()) {
+		t.Errorf("Expected missing enemy types to be filled in, got %d", len(cfg.Enemies.Types))
+	}
+}
+
+func TestParseRejectsBadValues(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"negative speed", "player:\n  speed: -1\n", "player.speed"},
+		{"zero lives", "player:\n  lives: 0\n", "player.lives"},
+		{"extra lives out of order", "player:\n  extra_lives: [500, 200]\n", "player.extra_lives[1]"},
+		{"unknown projectile", "player:\n  projectile: \"plasma\"\n", "player.projectile"},
+		{"zero spread count", "player:\n  spread:\n    count: 0\n", "player.spread.count"},
+		{"zero fps", "game:\n  fps: 0\n", "game.fps"},
+		{"no high scores", "game:\n  high_scores: 0\n", "game.high_scores"},
+		{"unknown enemy", "enemies:\n  types:\n    - name: \"boss\"\n      health: 5\n      speed: 5\n", "enemies.types[0].name"},
+		{"zero enemy health", "enemies:\n  types:\n    - name: \"tank\"\n      health: 0\n", "enemies.types[0].health"},
+		{"no spawn weight", "enemies:\n  types:\n    - { name: \"basic\", spawn_weight: 0 }\n    - { name: \"fast\", spawn_weight: 0 }\n    - { name: \"tank\", spawn_weight: 0 }\n    - { name: \"shooter\", spawn_weight: 0 }\n", "spawn_weight must be positive"},
+		{"column chance above 1", "enemies:\n  column_chance: 1.5\n", "enemies.column_chance"},
+		{"unknown resistance", "enemies:\n  types:\n    - name: \"tank\"\n      defence:\n        resistances: { plasma: 0.5 }\n", "enemies.types[0].defence.resistances"},
+		{"negative armour", "enemies:\n  types:\n    - name: \"tank\"\n      defence:\n        armour: -1\n", "enemies.types[0].defence.armour"},
+		{"unknown fire pattern", "enemies:\n  types:\n    - name: \"shooter\"\n      fire_pattern: \"spiral\"\n", "enemies.types[0].fire_pattern"},
+		{"unknown movement", "enemies:\n  types:\n    - name: \"fast\"\n      movement: \"teleport\"\n", "enemies.types[0].movement"},
+		{"unknown drop", "enemies:\n  types:\n    - name: \"basic\"\n      drops: { nuke: 1 }\n", "enemies.types[0].drops"},
+		{"boss without zones", "bosses:\n  - name: \"hulk\"\n    health: 100\n    speed: 50\n    width: 80\n    height: 40\n    phases:\n      - threshold: 1\n", "bosses[0].zones"},
+		{"boss phase out of order", "bosses:\n  - name: \"hulk\"\n    health: 100\n    speed: 50\n    width: 80\n    height: 40\n    zones:\n      - { width: 80, height: 40, multiplier: 1 }\n    phases:\n      - threshold: 1\n      - threshold: 1\n", "bosses[0].phases[1].threshold"},
+		{"adaptivity above 1", "difficulty:\n  adaptivity: 2\n", "difficulty.adaptivity"},
+		{"zero difficulty window", "difficulty:\n  window: 0\n", "difficulty.window"},
+		{"low max multiplier", "difficulty:\n  max_multiplier: 0.5\n", "difficulty.max_multiplier"},
+		{"zero combo window", "scoring:\n  combo_window: 0\n", "scoring.combo_window"},
+		{"accuracy threshold above 1", "scoring:\n  accuracy_threshold: 1.5\n", "scoring.accuracy_threshold"},
+		{"unknown theme", "background:\n  theme: \"void\"\n", "background.theme"},
+		{"zero scroll speed", "background:\n  scroll_speed: 0\n", "background.scroll_speed"},
+		{"bloom threshold of 1", "postfx:\n  bloom_threshold: 1\n", "postfx.bloom_threshold"},
+		{"zero flash time", "postfx:\n  flash_time: 0\n", "postfx.flash_time"},
+		{"malformed", "player: [", "parsing config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.yaml))
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error mentioning %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package director
+
+import (
+	"math"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+)
+
+// Difficulty sets how hard the game plays. Its level climbs steadily
+// with time and is pushed above or below that ramp by how well the
+// player is doing, moving smoothly so changes aren't felt as jumps.
+type Difficulty struct {
+	cfg config.DifficultyConfig
+
+	time   float64
+	level  float64
+	target float64
+	skill  float64
+
+	// Recent play, decaying over the configured window
+	health     float64
+	shots      float64
+	hits       float64
+	kills      float64
+	killTime   float64
+	healthLost float64
+}
+
+// Snapshot is the difficulty director's state for the HUD and telemetry.
+// HitRate and KillTime are zero until there have been shots and kills.
+type Snapshot struct {
+	Level      float64
+	Target     float64
+	Skill      float64
+	Health     float64
+	HitRate    float64
+	KillTime   float64
+	HealthLost float64
+	EnemySpeed float64
+	FireRate   float64
+}
+
+// NewDifficulty creates a director at the starting level
+func NewDifficulty(cfg config.DifficultyConfig) *Difficulty {
+	return &Difficulty{
+		cfg:    cfg,
+		level:  1,
+		target: 1,
+		health: 1,
+	}
+}
+
+// ShotFired records a shot by the player
+func (d *Difficulty) ShotFired() {
+	d.shots++
+}
+
+// ShotHit records a player shot hitting an enemy
+func (d *Difficulty) ShotHit() {
+	d.hits++
+}
+
+// Kill records an enemy shot down lifetime seconds after it appeared
+func (d *Difficulty) Kill(lifetime float64) {
+	d.kills++
+	d.killTime += lifetime
+}
+
+// Update advances the director by dt with the player's health as a
+// fraction of their maximum
+func (d *Difficulty) Update(dt, health float64) {
+	d.time += dt
+
+	decay := math.Exp(-dt / d.cfg.Window)
+	d.shots *= decay
+	d.hits *= decay
+	d.kills *= decay
+	d.killTime *= decay
+	d.healthLost *= decay
+	if health < d.health {
+		d.healthLost += d.health - health
+	}
+	d.health = health
+
+	d.skill = d.assess()
+	ramp := 1 + d.time*d.cfg.IncreaseRate
+	d.target = clamp(ramp*(1+d.cfg.Adaptivity*d.skill), 1, d.cfg.MaxMultiplier)
+
+	if d.cfg.Smoothing <= 0 {
+		d.level = d.target
+	} else {
+		d.level += (d.target - d.level) * (1 - math.Exp(-dt/d.cfg.Smoothing))
+	}
+}
+
+// assess rates recent play from -1, struggling, to 1, cruising.
+// Measures without enough recent data count as neutral.
+func (d *Difficulty) assess() float64 {
+	health := d.health*2 - 1
+
+	hitRate := 0.0
+	if d.shots >= 1 {
+		hitRate = clamp((d.hits/d.shots-d.cfg.TargetHitRate)/d.cfg.TargetHitRate, -1, 1)
+	}
+
+	killSpeed := 0.0
+	if d.kills >= 0.5 {
+		killSpeed = clamp((d.cfg.TargetKillTime-d.killTime/d.kills)/d.cfg.TargetKillTime, -1, 1)
+	}
+
+	damage := 1 - 2*clamp(d.healthLost/d.cfg.DamageTolerance, 0, 1)
+
+	return (health + hitRate + killSpeed + damage) / 4
+}
+
+// Level returns the current difficulty multiplier, from 1 up to the
+// configured maximum
+func (d *Difficulty) Level() float64 {
+	return d.level
+}
+
+// SpawnInterval returns the time between random spawns given the base interval
+func (d *Difficulty) SpawnInterval(base float64) float64 {
+	return math.Max(base/d.level, d.cfg.SpawnRateMin)
+}
+
+// EnemySpeed returns the multiplier for the speed of new enemies
+func (d *Difficulty) EnemySpeed() float64 {
+	return 1 + (d.cfg.MaxEnemySpeed-1)*d.progress()
+}
+
+// FireRate returns the multiplier for how often new enemies fire
+func (d *Difficulty) FireRate() float64 {
+	return 1 + (d.cfg.MaxFireRate-1)*d.progress()
+}
+
+// Snapshot returns the director's current state
+func (d *Difficulty) Snapshot() Snapshot {
+	s := Snapshot{
+		Level:      d.level,
+		Target:     d.target,
+		Skill:      d.skill,
+		Health:     d.health,
+		HealthLost: d.healthLost,
+		EnemySpeed: d.EnemySpeed(),
+		FireRate:   d.FireRate(),
+	}
+	if d.shots > 0 {
+		s.HitRate = d.hits / d.shots
+	}
+	if d.kills > 0 {
+		s.KillTime = d.killTime / d.kills
+	}
+	return s
+}
+
+// progress returns how far the level has come from 1 toward the maximum
+func (d *Difficulty) progress() float64 {
+	if d.cfg.MaxMultiplier <= 1 {
+		return 0
+	}
+	return clamp((d.level-1)/(d.cfg.MaxMultiplier-1), 0, 1)
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
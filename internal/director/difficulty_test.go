@@ -0,0 +1,128 @@
+package director
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+)
+
+const dt = 1.0 / 60
+
+// trace is a simulated player, acting once per second
+type trace struct {
+	shots      int
+	hits       int
+	killTime   float64
+	healthLoss float64 // fraction of max health lost per second
+	healing    float64 // fraction of max health regained per second
+}
+
+var (
+	cruising   = trace{shots: 6, hits: 4, killTime: 1.5}
+	average    = trace{shots: 6, hits: 2, killTime: 4, healthLoss: 0.01, healing: 0.01}
+	struggling = trace{shots: 6, hits: 1, killTime: 9, healthLoss: 0.04, healing: 0.035}
+)
+
+// play runs a director through seconds of the trace and returns it
+func play(cfg config.DifficultyConfig, tr trace, seconds int) *Difficulty {
+	d := NewDifficulty(cfg)
+	health := 1.0
+	for s := 0; s < seconds; s++ {
+		for i := 0; i < tr.shots; i++ {
+			d.ShotFired()
+		}
+		for i := 0; i < tr.hits; i++ {
+			d.ShotHit()
+		}
+		if tr.killTime > 0 {
+			d.Kill(tr.killTime)
+		}
+
+		// Damage lands at once, healing comes in between
+		health = math.Max(0.05, health-tr.healthLoss)
+		for i := 0; i < 60; i++ {
+			d.Update(dt, health)
+		}
+		health = math.Min(1, health+tr.healing)
+	}
+	return d
+}
+
+func TestDifficultyFollowsPerformance(t *testing.T) {
+	cfg := config.Default().Difficulty
+
+	strong := play(cfg, cruising, 60)
+	middle := play(cfg, average, 60)
+	weak := play(cfg, struggling, 60)
+
+	if !(strong.Level() > middle.Level() && middle.Level() > weak.Level()) {
+		t.Errorf("Expected cruising > average > struggling, got %.2f, %.2f, %.2f", strong.Level(), middle.Level(), weak.Level())
+	}
+
+	ramp := 1 + 60*cfg.IncreaseRate
+	if strong.Level() <= ramp {
+		t.Errorf("Expected a cruising player above the time ramp %.2f, got %.2f", ramp, strong.Level())
+	}
+	if weak.Level() >= ramp {
+		t.Errorf("Expected a struggling player below the time ramp %.2f, got %.2f", ramp, weak.Level())
+	}
+
+	if s := strong.Snapshot(); s.Skill <= 0 || s.HitRate < 0.6 {
+		t.Errorf("Expected the cruising snapshot to show skill and hits, got %+v", s)
+	}
+	if s := weak.Snapshot(); s.Skill >= 0 || s.KillTime < 8 {
+		t.Errorf("Expected the struggling snapshot to show slow kills, got %+v", s)
+	}
+}
+
+func TestDifficultyStaysInBounds(t *testing.T) {
+	cfg := config.Default().Difficulty
+
+	strong := play(cfg, cruising, 600)
+	if strong.Level() > cfg.MaxMultiplier {
+		t.Errorf("Expected at most %g, got %g", cfg.MaxMultiplier, strong.Level())
+	}
+	if math.Abs(strong.EnemySpeed()-cfg.MaxEnemySpeed) > 0.01 || math.Abs(strong.FireRate()-cfg.MaxFireRate) > 0.01 {
+		t.Errorf("Expected maximum enemy speed and fire rate, got %g and %g", strong.EnemySpeed(), strong.FireRate())
+	}
+	if got := strong.SpawnInterval(2); got != cfg.SpawnRateMin {
+		t.Errorf("Expected the minimum spawn interval, got %g", got)
+	}
+
+	weak := play(cfg, struggling, 10)
+	if weak.Level() < 1 || weak.EnemySpeed() < 1 || weak.FireRate() < 1 {
+		t.Errorf("Expected nothing below the starting level, got %+v", weak.Snapshot())
+	}
+}
+
+func TestDifficultyChangesSmoothly(t *testing.T) {
+	cfg := config.Default().Difficulty
+	d := play(cfg, cruising, 30)
+
+	// A sudden collapse shouldn't drop the level in one go
+	before := d.Level()
+	for i := 0; i < 60; i++ {
+		prev := d.Level()
+		d.Update(dt, 0.1)
+		if step := math.Abs(d.Level() - prev); step > 0.05 {
+			t.Fatalf("Level jumped by %.3f in one tick", step)
+		}
+	}
+	if d.Level() >= before {
+		t.Errorf("Expected the level to ease down after heavy damage, %.2f -> %.2f", before, d.Level())
+	}
+}
+
+func TestDifficultyWithoutAdaptivityFollowsTime(t *testing.T) {
+	cfg := config.Default().Difficulty
+	cfg.Adaptivity = 0
+	cfg.Smoothing = 0
+
+	for _, tr := range []trace{cruising, struggling} {
+		d := play(cfg, tr, 60)
+		if want := 1 + 60*cfg.IncreaseRate; math.Abs(d.Level()-want) > 1e-6 {
+			t.Errorf("Expected the time ramp %.3f, got %.3f", want, d.Level())
+		}
+	}
+}
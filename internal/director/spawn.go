@@ -0,0 +1,103 @@
+// Package director decides how hard the game plays: which enemies spawn
+// at random and where.
+package director
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/entities"
+)
+
+// Spawner picks random enemies from weighted tables that shift toward
+// tougher types as difficulty rises
+type Spawner struct {
+	enemies       config.EnemiesConfig
+	maxDifficulty float64
+	stats         []config.EnemyTypeConfig
+	weights       []float64
+}
+
+// NewSpawner creates a spawner driven by cfg's enemy and difficulty settings
+func NewSpawner(cfg *config.Config) *Spawner {
+	s := &Spawner{
+		enemies:       cfg.Enemies,
+		maxDifficulty: cfg.Difficulty.MaxMultiplier,
+		weights:       make([]float64, len(entities.EnemyTypes)),
+	}
+	for _, t := range entities.EnemyTypes {
+		stats, _ := cfg.Enemies.EnemyType(t.String())
+		s.stats = append(s.stats, stats)
+	}
+	return s
+}
+
+// Weights returns the spawn weight of each of entities.EnemyTypes at a
+// difficulty multiplier. The slice is reused.
+func (s *Spawner) Weights(difficulty float64) []float64 {
+	// How far difficulty has come from 1 toward its maximum
+	progress := 0.0
+	if s.maxDifficulty > 1 {
+		progress = math.Max(0, math.Min(1, (difficulty-1)/(s.maxDifficulty-1)))
+	}
+
+	for i, stats := range s.stats {
+		s.weights[i] = stats.SpawnWeight + (stats.LateSpawnWeight-stats.SpawnWeight)*progress
+	}
+	return s.weights
+}
+
+// Pick chooses an enemy type by weight
+func (s *Spawner) Pick(rng *rand.Rand, difficulty float64) entities.EnemyType {
+	weights := s.Weights(difficulty)
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+
+	roll := rng.Float64() * total
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		roll -= w
+		if roll < 0 {
+			return entities.EnemyTypes[i]
+		}
+	}
+	return entities.EnemyTypes[len(entities.EnemyTypes)-1]
+}
+
+// Column chooses the x position a spawn enters at, keeping most spawns
+// out of the column above the player
+func (s *Spawner) Column(rng *rand.Rand, world *entities.World) float64 {
+	x := rng.Float64() * world.Width
+	if !world.HasPlayer || math.Abs(x-world.Player.X) >= s.enemies.SafeColumn || rng.Float64() < s.enemies.ColumnChance {
+		return x
+	}
+
+	// Move the spawn to a point either side of the column
+	left := math.Max(0, world.Player.X-s.enemies.SafeColumn)
+	right := math.Max(0, world.Width-(world.Player.X+s.enemies.SafeColumn))
+	if left+right <= 0 {
+		return x
+	}
+	offset := rng.Float64() * (left + right)
+	if offset < left {
+		return offset
+	}
+	return world.Width - (offset - left)
+}
+
+// Spawn creates a random enemy entering at the top of the screen, or
+// returns nil while alive enemies are at the cap
+func (s *Spawner) Spawn(rng *rand.Rand, difficulty float64, alive int, world *entities.World) *entities.Enemy {
+	if alive >= s.enemies.MaxEnemies {
+		return nil
+	}
+
+	enemyType := s.Pick(rng, difficulty)
+	x := s.Column(rng, world)
+	return entities.NewEnemy(enemyType, x, -30, world.Width, world.Height, s.stats[enemyType])
+}
@@ -0,0 +1,125 @@
+package director
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/entities"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+var testWorld = entities.World{Player: vector.New(400, 500), HasPlayer: true, Width: 800, Height: 600}
+
+func TestWeightsShiftWithDifficulty(t *testing.T) {
+	cfg := config.Default()
+	s := NewSpawner(cfg)
+
+	for i, w := range s.Weights(1) {
+		stats, _ := cfg.Enemies.EnemyType(entities.EnemyTypes[i].String())
+		if w != stats.SpawnWeight {
+			t.Errorf("%s: expected weight %g at the start, got %g", entities.EnemyTypes[i], stats.SpawnWeight, w)
+		}
+	}
+	for i, w := range s.Weights(cfg.Difficulty.MaxMultiplier * 2) {
+		stats, _ := cfg.Enemies.EnemyType(entities.EnemyTypes[i].String())
+		if w != stats.LateSpawnWeight {
+			t.Errorf("%s: expected late weight %g past the maximum, got %g", entities.EnemyTypes[i], stats.LateSpawnWeight, w)
+		}
+	}
+
+	early := s.Weights(1)[entities.EnemyShooter]
+	mid := s.Weights(3)[entities.EnemyShooter]
+	if mid <= early {
+		t.Errorf("Expected shooters to grow more likely with difficulty, %g -> %g", early, mid)
+	}
+}
+
+func TestPickFollowsWeights(t *testing.T) {
+	cfg := config.Default()
+	s := NewSpawner(cfg)
+	rng := rand.New(rand.NewSource(1))
+
+	const picks = 20000
+	counts := make(map[entities.EnemyType]int)
+	for i := 0; i < picks; i++ {
+		counts[s.Pick(rng, 1)]++
+	}
+
+	total := 0.0
+	for _, w := range s.Weights(1) {
+		total += w
+	}
+	for i, w := range s.Weights(1) {
+		want := w / total
+		got := float64(counts[entities.EnemyTypes[i]]) / picks
+		if math.Abs(got-want) > 0.02 {
+			t.Errorf("%s: expected about %.2f of spawns, got %.2f", entities.EnemyTypes[i], want, got)
+		}
+	}
+}
+
+func TestPickSkipsZeroWeights(t *testing.T) {
+	cfg := config.Default()
+	for i := range cfg.Enemies.Types {
+		if cfg.Enemies.Types[i].Name != config.EnemyTank {
+			cfg.Enemies.Types[i].SpawnWeight = 0
+		}
+	}
+	s := NewSpawner(cfg)
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		if got := s.Pick(rng, 1); got != entities.EnemyTank {
+			t.Fatalf("Expected only tanks, got %s", got)
+		}
+	}
+}
+
+func TestSpawnCapsEnemies(t *testing.T) {
+	cfg := config.Default()
+	s := NewSpawner(cfg)
+	rng := rand.New(rand.NewSource(1))
+
+	if s.Spawn(rng, 1, cfg.Enemies.MaxEnemies, &testWorld) != nil {
+		t.Error("Expected no spawn at the enemy cap")
+	}
+	enemy := s.Spawn(rng, 1, cfg.Enemies.MaxEnemies-1, &testWorld)
+	if enemy == nil {
+		t.Fatal("Expected a spawn below the enemy cap")
+	}
+	if pos := enemy.GetPosition(); pos.Y >= 0 || pos.X < 0 || pos.X > testWorld.Width {
+		t.Errorf("Expected the enemy to enter above the screen, at %v", pos)
+	}
+}
+
+func TestColumnAvoidsPlayer(t *testing.T) {
+	cfg := config.Default()
+	cfg.Enemies.ColumnChance = 0
+	s := NewSpawner(cfg)
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		x := s.Column(rng, &testWorld)
+		if math.Abs(x-testWorld.Player.X) < cfg.Enemies.SafeColumn {
+			t.Fatalf("Spawn at x=%g is inside the player's column", x)
+		}
+		if x < 0 || x > testWorld.Width {
+			t.Fatalf("Spawn at x=%g is off screen", x)
+		}
+	}
+
+	// Some spawns still drop on the player when allowed
+	cfg.Enemies.ColumnChance = 0.5
+	s = NewSpawner(cfg)
+	inColumn := 0
+	for i := 0; i < 1000; i++ {
+		if math.Abs(s.Column(rng, &testWorld)-testWorld.Player.X) < cfg.Enemies.SafeColumn {
+			inColumn++
+		}
+	}
+	if inColumn == 0 {
+		t.Error("Expected some spawns in the player's column with column_chance 0.5")
+	}
+}
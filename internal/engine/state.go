@@ -8,6 +8,8 @@ const (
 	StatePlaying
 	StatePaused
 	StateGameOver
+	StateNameEntry
+	StateHighScores
 )
 
 // StateManager manages game states
@@ -0,0 +1,169 @@
+package entities
+
+import (
+	"math"
+
+	"github.com/EchoSingh/space-shooter/internal/assets"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// bankSpeed is how fast a ship must move sideways before it banks
+const bankSpeed = 40.0
+
+// Animator component playing a sprite's animations. A base animation
+// such as idle or banking plays until another replaces it, and one-shot
+// animations such as a hit flash play over it once.
+type Animator struct {
+	Sprite *assets.Sprite
+
+	base track
+	once track
+	done bool
+	op   ebiten.DrawImageOptions
+}
+
+// track is an animation and how far through it playback is
+type track struct {
+	anim    *assets.Animation
+	frame   int
+	elapsed float64
+}
+
+// advance moves the track on by dt and reports whether a one-shot
+// animation has reached its end, where it then stays
+func (t *track) advance(dt float64) bool {
+	t.elapsed += dt
+	step := 1 / t.anim.FPS
+	for t.elapsed >= step {
+		t.elapsed -= step
+		switch {
+		case t.frame+1 < len(t.anim.Frames):
+			t.frame++
+		case t.anim.Loop:
+			t.frame = 0
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// NewAnimator creates an animator playing sprite's idle animation. Without
+// a sprite there is nothing to animate, so it returns nil and the entity
+// draws as a plain shape.
+func NewAnimator(sprite *assets.Sprite) *Animator {
+	if sprite == nil {
+		return nil
+	}
+	a := &Animator{Sprite: sprite}
+	a.Play(assets.AnimIdle)
+	return a
+}
+
+// Play makes the named animation the base animation, carrying on if it is
+// already playing. A base animation that doesn't loop holds its last
+// frame, and the animator is then done. Unknown names are ignored.
+func (a *Animator) Play(name string) {
+	anim := a.Sprite.Animation(name)
+	if anim == nil || anim == a.base.anim {
+		return
+	}
+	a.base = track{anim: anim}
+	a.done = false
+}
+
+// Trigger plays the named animation once over the base animation, from
+// the start even if it is already playing
+func (a *Animator) Trigger(name string) {
+	if anim := a.Sprite.Animation(name); anim != nil {
+		a.once = track{anim: anim}
+	}
+}
+
+// Bank plays the banking animation for a ship moving sideways at vx, or
+// idle when it is flying straight
+func (a *Animator) Bank(vx float64) {
+	switch {
+	case vx < -bankSpeed:
+		a.Play(assets.AnimBankLeft)
+	case vx > bankSpeed:
+		a.Play(assets.AnimBankRight)
+	default:
+		a.Play(assets.AnimIdle)
+	}
+}
+
+// Update advances the playing animations
+func (a *Animator) Update(dt float64) {
+	if a.once.anim != nil && a.once.advance(dt) {
+		a.once = track{}
+	}
+	if a.base.anim != nil && !a.done {
+		a.done = a.base.advance(dt)
+	}
+}
+
+// Done reports whether a base animation that doesn't loop has finished
+func (a *Animator) Done() bool {
+	return a.done
+}
+
+// Frame returns the index of the sprite frame showing
+func (a *Animator) Frame() int {
+	t := a.base
+	if a.once.anim != nil {
+		t = a.once
+	}
+	if t.anim == nil {
+		return 0
+	}
+	return t.anim.Frames[t.frame]
+}
+
+// scale returns how much the sprite is stretched to fill v
+func (a *Animator) scale(v *Visual) (float64, float64) {
+	return v.Width / float64(a.Sprite.Size.X), v.Height / float64(a.Sprite.Size.Y)
+}
+
+// Fit sizes h to the showing frame's hitbox, scaled as the sprite is drawn
+// to fill v. A hitbox off the sprite's pivot becomes a polygon around it.
+func (a *Animator) Fit(h *Hitbox, v *Visual) {
+	sx, sy := a.scale(v)
+	r := a.Sprite.Frames[a.Frame()].Hitbox
+	pivot := a.Sprite.Pivot
+
+	h.Width = float64(r.Dx()) * sx
+	h.Height = float64(r.Dy()) * sy
+	h.Points = h.Points[:0]
+
+	cx := (float64(r.Min.X+r.Max.X)/2 - float64(pivot.X)) * sx
+	cy := (float64(r.Min.Y+r.Max.Y)/2 - float64(pivot.Y)) * sy
+	if math.Abs(cx) > 1e-9 || math.Abs(cy) > 1e-9 {
+		hw, hh := h.Width/2, h.Height/2
+		h.Points = append(h.Points,
+			vector.New(cx-hw, cy-hh),
+			vector.New(cx+hw, cy-hh),
+			vector.New(cx+hw, cy+hh),
+			vector.New(cx-hw, cy+hh),
+		)
+	}
+}
+
+// Draw draws the showing frame at pos, scaled to fill v and turned by its
+// Angle around the sprite's pivot
+func (a *Animator) Draw(screen *ebiten.Image, pos vector.Vector2, v *Visual) {
+	sx, sy := a.scale(v)
+	a.op.GeoM.Reset()
+	a.op.GeoM.Translate(-float64(a.Sprite.Pivot.X), -float64(a.Sprite.Pivot.Y))
+	a.op.GeoM.Scale(sx, sy)
+	a.op.GeoM.Rotate(v.Angle)
+	a.op.GeoM.Translate(pos.X, pos.Y)
+	screen.DrawImage(a.Sprite.Image(a.Frame()), &a.op)
+}
+
+// Clone returns a copy of the animator that plays on independently
+func (a *Animator) Clone() *Animator {
+	c := *a
+	return &c
+}
@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"image"
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/assets"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+// testSprite is a 10x20 ship with two idle frames, a banking frame with
+// a narrow hitbox off to one side, a hit flash and two death frames
+func testSprite() *assets.Sprite {
+	whole := image.Rect(0, 0, 10, 20)
+	return &assets.Sprite{
+		Name:  "ship",
+		Size:  image.Pt(10, 20),
+		Pivot: image.Pt(5, 10),
+		Frames: []assets.Frame{
+			{Hitbox: whole},
+			{Hitbox: whole},
+			{Hitbox: image.Rect(0, 0, 6, 20)},
+			{Hitbox: whole},
+			{Hitbox: whole},
+			{Hitbox: whole},
+		},
+		Animations: map[string]*assets.Animation{
+			assets.AnimIdle:     {Name: assets.AnimIdle, Frames: []int{0, 1}, FPS: 10, Loop: true},
+			assets.AnimBankLeft: {Name: assets.AnimBankLeft, Frames: []int{2}, FPS: 1, Loop: true},
+			assets.AnimHit:      {Name: assets.AnimHit, Frames: []int{3}, FPS: 10},
+			assets.AnimDeath:    {Name: assets.AnimDeath, Frames: []int{4, 5}, FPS: 10},
+		},
+	}
+}
+
+func TestAnimatorLoopsIdle(t *testing.T) {
+	a := NewAnimator(testSprite())
+	if a.Frame() != 0 {
+		t.Fatalf("Expected to start on frame 0, got %d", a.Frame())
+	}
+
+	a.Update(0.15)
+	if a.Frame() != 1 {
+		t.Errorf("Expected frame 1 after a frame's time, got %d", a.Frame())
+	}
+	a.Update(0.1)
+	if a.Frame() != 0 || a.Done() {
+		t.Errorf("Expected idle to loop round, got frame %d", a.Frame())
+	}
+}
+
+func TestAnimatorHitFlashReturnsToBase(t *testing.T) {
+	a := NewAnimator(testSprite())
+	a.Update(0.15)
+
+	a.Trigger(assets.AnimHit)
+	if a.Frame() != 3 {
+		t.Fatalf("Expected the hit frame, got %d", a.Frame())
+	}
+	a.Update(0.1)
+	if a.Frame() == 3 {
+		t.Error("Expected the flash to end after one pass")
+	}
+	if a.Done() {
+		t.Error("Expected a one-shot over idle not to finish the animator")
+	}
+}
+
+func TestAnimatorBanksAndFitsHitbox(t *testing.T) {
+	a := NewAnimator(testSprite())
+	visual := &Visual{Width: 20, Height: 40}
+	hitbox := &Hitbox{}
+
+	a.Bank(-100)
+	a.Fit(hitbox, visual)
+	if a.Frame() != 2 {
+		t.Fatalf("Expected the bank left frame, got %d", a.Frame())
+	}
+	if hitbox.Width != 12 || hitbox.Height != 40 {
+		t.Errorf("Expected a 12x40 hitbox scaled to the visual, got %gx%g", hitbox.Width, hitbox.Height)
+	}
+	// The banked hitbox sits left of the pivot, so it is a polygon
+	if len(hitbox.Points) != 4 || hitbox.Points[0] != vector.New(-10, -20) || hitbox.Points[2] != vector.New(2, 20) {
+		t.Errorf("Expected corners from (-10,-20) to (2,20), got %v", hitbox.Points)
+	}
+
+	// There's no bank right animation, so the ship stays as it was
+	a.Bank(100)
+	if a.Frame() != 2 {
+		t.Errorf("Expected an unknown animation to be ignored, got frame %d", a.Frame())
+	}
+
+	a.Bank(0)
+	a.Fit(hitbox, visual)
+	if a.Frame() != 0 || len(hitbox.Points) != 0 || hitbox.Width != 20 {
+		t.Errorf("Expected idle with a centred box, got frame %d with %v", a.Frame(), hitbox)
+	}
+}
+
+func TestWreckPlaysDeathOnce(t *testing.T) {
+	a := NewAnimator(testSprite())
+	a.Trigger(assets.AnimHit)
+
+	w := NewWreck(a, vector.New(50, 50), vector.New(0, 100), &Visual{Width: 10, Height: 20})
+	if w == nil {
+		t.Fatal("Expected a wreck for a sprite with a death animation")
+	}
+	if w.Animator.Frame() != 4 {
+		t.Errorf("Expected the first death frame, got %d", w.Animator.Frame())
+	}
+	if a.Frame() != 3 {
+		t.Error("Expected the ship's own animator to be left alone")
+	}
+
+	for i := 0; i < 3 && w.IsActive(); i++ {
+		_ = w.Update(0.1)
+	}
+	if w.IsActive() {
+		t.Error("Expected the wreck to go once its death animation ends")
+	}
+	if w.Position.Y <= 50 {
+		t.Error("Expected the wreck to drift on")
+	}
+
+	if NewWreck(nil, vector.Zero(), vector.Zero(), &Visual{}) != nil {
+		t.Error("Expected no wreck without a sprite")
+	}
+}
+
+func TestNewAnimatorWithoutSprite(t *testing.T) {
+	if NewAnimator(nil) != nil {
+		t.Error("Expected no animator without a sprite")
+	}
+}
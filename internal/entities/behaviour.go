@@ -0,0 +1,265 @@
+package entities
+
+import (
+	"math"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+// World is what enemies can see of the game when choosing where to move.
+// Game updates it every tick and shares it with every enemy.
+type World struct {
+	Player    vector.Vector2
+	HasPlayer bool
+	Time      float64
+	Width     float64
+	Height    float64
+}
+
+// Behaviour decides an enemy's velocity each tick
+type Behaviour interface {
+	Velocity(e *Enemy, world *World, dt float64) vector.Vector2
+}
+
+// ParseMovePattern converts a config movement name
+func ParseMovePattern(name string) MovePattern {
+	switch name {
+	case config.MoveSine:
+		return PatternSine
+	case config.MoveZigZag:
+		return PatternZigZag
+	case config.MoveSeek:
+		return PatternSeek
+	case config.MoveStrafe:
+		return PatternStrafe
+	case config.MoveOrbit:
+		return PatternOrbit
+	case config.MoveDive:
+		return PatternDive
+	case config.MoveHover:
+		return PatternHover
+	}
+	return PatternStraight
+}
+
+// NewBehaviour creates a behaviour for a movement pattern with its
+// standard tuning. Formations need a shared Formation, so
+// PatternFormation moves straight until one is assigned.
+func NewBehaviour(pattern MovePattern) Behaviour {
+	switch pattern {
+	case PatternSine:
+		return &Sine{Amplitude: 100, Frequency: 2}
+	case PatternZigZag:
+		return &ZigZag{Speed: 150, Period: 1}
+	case PatternSeek:
+		return &Seek{TurnRate: 1.5}
+	case PatternStrafe:
+		return &Strafe{Line: 0.25, Hold: 4}
+	case PatternOrbit:
+		return &Orbit{Radius: 180, AngularSpeed: 1}
+	case PatternDive:
+		return &Dive{Line: 0.2, Delay: 0.8, Boost: 3}
+	case PatternHover:
+		return &Hover{Line: 0.2, Frequency: 0.8}
+	}
+	return Straight{}
+}
+
+// Straight flies straight down
+type Straight struct{}
+
+// Velocity moves down at the enemy's speed
+func (Straight) Velocity(e *Enemy, world *World, dt float64) vector.Vector2 {
+	return vector.New(0, e.Speed)
+}
+
+// Sine weaves from side to side while descending
+type Sine struct {
+	Amplitude float64
+	Frequency float64
+}
+
+// Velocity sways horizontally with the enemy's age
+func (s *Sine) Velocity(e *Enemy, world *World, dt float64) vector.Vector2 {
+	return vector.New(math.Sin(e.Time*s.Frequency)*s.Amplitude, e.Speed)
+}
+
+// ZigZag switches sideways direction every Period seconds while descending
+type ZigZag struct {
+	Speed  float64
+	Period float64
+}
+
+// Velocity alternates left and right
+func (z *ZigZag) Velocity(e *Enemy, world *World, dt float64) vector.Vector2 {
+	direction := 1.0
+	if int(math.Floor(e.Time/z.Period))%2 == 0 {
+		direction = -1.0
+	}
+	return vector.New(direction*z.Speed, e.Speed)
+}
+
+// Seek homes in on the player, turning at most TurnRate radians per second
+type Seek struct {
+	TurnRate float64
+}
+
+// Velocity turns the enemy's heading toward the player
+func (s *Seek) Velocity(e *Enemy, world *World, dt float64) vector.Vector2 {
+	heading := e.Velocity
+	if heading == vector.Zero() {
+		heading = vector.New(0, 1)
+	}
+	if world.HasPlayer {
+		heading = turnToward(heading, world.Player.Sub(e.Position), s.TurnRate*dt)
+	}
+	return heading.Normalize().Mul(e.Speed)
+}
+
+// Strafe descends to a line Line of the way down the screen, tracks the
+// player sideways for Hold seconds, then carries on down
+type Strafe struct {
+	Line float64
+	Hold float64
+
+	held float64
+}
+
+// Velocity follows the player's x position while holding the line
+func (s *Strafe) Velocity(e *Enemy, world *World, dt float64) vector.Vector2 {
+	if e.Position.Y < world.Height*s.Line || s.held >= s.Hold {
+		return vector.New(0, e.Speed)
+	}
+
+	s.held += dt
+	vx := 0.0
+	if world.HasPlayer {
+		vx = math.Max(-e.Speed, math.Min(e.Speed, (world.Player.X-e.Position.X)*4))
+	}
+	return vector.New(vx, 0)
+}
+
+// Orbit circles the player at Radius, AngularSpeed radians per second
+type Orbit struct {
+	Radius       float64
+	AngularSpeed float64
+
+	angle   float64
+	started bool
+}
+
+// Velocity chases a point travelling round the player
+func (o *Orbit) Velocity(e *Enemy, world *World, dt float64) vector.Vector2 {
+	if !world.HasPlayer {
+		return vector.New(0, e.Speed)
+	}
+	if !o.started {
+		o.angle = e.Position.Sub(world.Player).Angle()
+		o.started = true
+	}
+
+	o.angle += o.AngularSpeed * dt
+	target := world.Player.Add(vector.New(math.Cos(o.angle), math.Sin(o.angle)).Mul(o.Radius))
+
+	// Stay on screen rather than orbiting out of play
+	target = target.Clamp(vector.Zero(), vector.New(world.Width, world.Height))
+	return approach(target.Sub(e.Position), 2*e.Speed, dt)
+}
+
+// Dive descends to a line Line of the way down the screen, hovers for
+// Delay seconds, then dives at Boost times its speed at where the player was
+type Dive struct {
+	Line  float64
+	Delay float64
+	Boost float64
+
+	hovered float64
+	dive    vector.Vector2
+}
+
+// Velocity runs the enter, hover and dive phases
+func (d *Dive) Velocity(e *Enemy, world *World, dt float64) vector.Vector2 {
+	switch {
+	case d.dive != vector.Zero():
+		return d.dive
+	case e.Position.Y < world.Height*d.Line:
+		return vector.New(0, e.Speed)
+	case d.hovered < d.Delay:
+		d.hovered += dt
+		return vector.Zero()
+	}
+
+	dir := vector.New(0, 1)
+	if world.HasPlayer {
+		if to := world.Player.Sub(e.Position).Normalize(); to != vector.Zero() {
+			dir = to
+		}
+	}
+	d.dive = dir.Mul(e.Speed * d.Boost)
+	return d.dive
+}
+
+// Hover descends to a line Line of the way down the screen, then sweeps
+// from side to side along it, Frequency radians of sway per second
+type Hover struct {
+	Line      float64
+	Frequency float64
+
+	swayed float64
+}
+
+// Velocity enters, then sways at the enemy's speed
+func (h *Hover) Velocity(e *Enemy, world *World, dt float64) vector.Vector2 {
+	if e.Position.Y < world.Height*h.Line {
+		return vector.New(0, e.Speed)
+	}
+	h.swayed += dt
+	return vector.New(e.Speed*math.Cos(h.swayed*h.Frequency), 0)
+}
+
+// Formation is a moving anchor that a group of enemies keep station on.
+// The anchor starts at Origin at world time Start, travels at Velocity
+// and sways Sway pixels from side to side.
+type Formation struct {
+	Origin   vector.Vector2
+	Velocity vector.Vector2
+	Sway     float64
+	Start    float64
+}
+
+// Anchor returns the formation's position at world time t
+func (f *Formation) Anchor(t float64) vector.Vector2 {
+	elapsed := t - f.Start
+	return f.Origin.Add(f.Velocity.Mul(elapsed)).Add(vector.New(math.Sin(elapsed)*f.Sway, 0))
+}
+
+// FormationFollow keeps an enemy at Offset from its formation's anchor
+type FormationFollow struct {
+	Formation *Formation
+	Offset    vector.Vector2
+}
+
+// Velocity closes on the enemy's slot in the formation
+func (f *FormationFollow) Velocity(e *Enemy, world *World, dt float64) vector.Vector2 {
+	slot := f.Formation.Anchor(world.Time).Add(f.Offset)
+	return approach(slot.Sub(e.Position), 2*e.Speed, dt)
+}
+
+// approach returns the velocity covering offset this tick, capped at maxSpeed
+func approach(offset vector.Vector2, maxSpeed, dt float64) vector.Vector2 {
+	if offset.Length() <= maxSpeed*dt {
+		return offset.Div(dt)
+	}
+	return offset.Normalize().Mul(maxSpeed)
+}
+
+// turnToward rotates heading toward want by at most maxTurn radians
+func turnToward(heading, want vector.Vector2, maxTurn float64) vector.Vector2 {
+	if want == vector.Zero() {
+		return heading
+	}
+	turn := math.Remainder(want.Angle()-heading.Angle(), 2*math.Pi)
+	turn = math.Max(-maxTurn, math.Min(maxTurn, turn))
+	return heading.Rotate(turn)
+}
@@ -0,0 +1,117 @@
+package entities
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+var testBasicEnemy, _ = config.Default().Enemies.EnemyType(config.EnemyBasic)
+
+func newTestWorld(player vector.Vector2) *World {
+	return &World{Player: player, HasPlayer: true, Width: 800, Height: 600}
+}
+
+func TestSeekTurnsTowardPlayer(t *testing.T) {
+	e := NewEnemy(EnemyBasic, 100, 100, 800, 600, testBasicEnemy)
+	e.Behaviour = &Seek{TurnRate: 2}
+	e.World = newTestWorld(vector.New(500, 100))
+
+	for i := 0; i < 60; i++ {
+		_ = e.Update(1.0 / 60)
+	}
+
+	if e.Velocity.X <= 0 {
+		t.Errorf("Expected seeker to turn toward the player on its right, velocity %v", e.Velocity)
+	}
+	if math.Abs(e.Velocity.Length()-e.Speed) > 1e-9 {
+		t.Errorf("Seeker should keep its speed, got %g", e.Velocity.Length())
+	}
+}
+
+func TestStrafeTracksPlayer(t *testing.T) {
+	e := NewEnemy(EnemyBasic, 100, 200, 800, 600, testBasicEnemy)
+	e.Behaviour = &Strafe{Line: 0.25, Hold: 10}
+	e.World = newTestWorld(vector.New(400, 500))
+
+	for i := 0; i < 240; i++ {
+		_ = e.Update(1.0 / 60)
+	}
+
+	if math.Abs(e.Position.X-400) > 5 {
+		t.Errorf("Expected strafer to line up with the player, at x=%g", e.Position.X)
+	}
+	if e.Position.Y != 200 {
+		t.Errorf("Strafer should hold its line, at y=%g", e.Position.Y)
+	}
+}
+
+func TestDiveHoversThenDives(t *testing.T) {
+	e := NewEnemy(EnemyBasic, 400, 120, 800, 600, testBasicEnemy)
+	e.Behaviour = &Dive{Line: 0.2, Delay: 0.5, Boost: 3}
+	e.World = newTestWorld(vector.New(400, 500))
+
+	_ = e.Update(0.1)
+	if e.Velocity != vector.Zero() {
+		t.Errorf("Expected diver to hover below its line, velocity %v", e.Velocity)
+	}
+
+	for i := 0; i < 10; i++ {
+		_ = e.Update(0.1)
+	}
+	if want := vector.New(0, e.Speed*3); e.Velocity.Distance(want) > 1e-9 {
+		t.Errorf("Expected dive velocity %v, got %v", want, e.Velocity)
+	}
+}
+
+func TestOrbitCirclesPlayer(t *testing.T) {
+	player := vector.New(400, 300)
+	e := NewEnemy(EnemyBasic, 580, 300, 800, 600, testBasicEnemy)
+	e.Behaviour = &Orbit{Radius: 180, AngularSpeed: 0.5}
+	e.World = newTestWorld(player)
+
+	for i := 0; i < 120; i++ {
+		_ = e.Update(1.0 / 60)
+	}
+
+	if d := e.Position.Distance(player); math.Abs(d-180) > 5 {
+		t.Errorf("Expected orbit radius 180, got %g", d)
+	}
+	if e.Position.Y <= 300 {
+		t.Errorf("Expected orbit to have moved round the player, at %v", e.Position)
+	}
+}
+
+func TestFormationFollowKeepsStation(t *testing.T) {
+	formation := &Formation{Origin: vector.New(400, 100), Velocity: vector.New(0, 20)}
+	world := newTestWorld(vector.New(400, 500))
+	e := NewEnemy(EnemyBasic, 430, 100, 800, 600, testBasicEnemy)
+	e.Behaviour = &FormationFollow{Formation: formation, Offset: vector.New(30, 0)}
+	e.World = world
+
+	for i := 0; i < 60; i++ {
+		world.Time += 1.0 / 60
+		_ = e.Update(1.0 / 60)
+	}
+
+	want := formation.Anchor(world.Time).Add(vector.New(30, 0))
+	if e.Position.Distance(want) > 1e-6 {
+		t.Errorf("Expected enemy at its slot %v, got %v", want, e.Position)
+	}
+}
+
+func TestMovementFromConfig(t *testing.T) {
+	stats := testBasicEnemy
+	stats.Movement = config.MoveSeek
+
+	e := NewEnemy(EnemyBasic, 100, 100, 800, 600, stats)
+
+	if e.MovePattern != PatternSeek {
+		t.Errorf("Expected seek pattern, got %v", e.MovePattern)
+	}
+	if _, ok := e.Behaviour.(*Seek); !ok {
+		t.Errorf("Expected seek behaviour, got %T", e.Behaviour)
+	}
+}
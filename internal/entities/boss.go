@@ -0,0 +1,215 @@
+package entities
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/render"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Boss is a large enemy made of hit zones. It moves and fires like an
+// Enemy, changing behaviour and weapon at each phase as its health falls.
+// The boss itself never collides; its zones do.
+type Boss struct {
+	Enemy
+	Name   string
+	Zones  []*BossZone
+	Phases []config.BossPhaseConfig
+	Phase  int
+
+	phaseChanged bool
+}
+
+// BossZone is one hit zone of a boss. Damage to it is scaled by
+// Multiplier and taken from the boss's health.
+type BossZone struct {
+	BaseEntity
+	Boss       *Boss
+	Name       string
+	Offset     vector.Vector2
+	Hitbox     *Hitbox
+	Multiplier float64
+}
+
+// NewBoss creates a boss from its config, centred at x, y
+func NewBoss(cfg config.BossConfig, x, y, screenWidth, screenHeight float64) *Boss {
+	boss := &Boss{
+		Enemy: Enemy{
+			BaseEntity: BaseEntity{
+				Position: vector.New(x, y),
+				Active:   true,
+				Type:     TypeEnemy,
+				Radius:   math.Max(cfg.Width, cfg.Height) / 2,
+			},
+			Health:     NewHealth(cfg.Health),
+			Defence:    NewDefence(cfg.Defence),
+			Speed:      cfg.Speed,
+			ScoreValue: cfg.Score,
+			Visual: &Visual{
+				Color:  color.RGBA{R: 120, G: 60, B: 160, A: 255},
+				Width:  cfg.Width,
+				Height: cfg.Height,
+			},
+			Hitbox:       &Hitbox{Width: cfg.Width, Height: cfg.Height},
+			screenWidth:  screenWidth,
+			screenHeight: screenHeight,
+		},
+		Name:   cfg.Name,
+		Phases: cfg.Phases,
+	}
+
+	for _, z := range cfg.Zones {
+		zone := &BossZone{
+			BaseEntity: BaseEntity{
+				Active: true,
+				Type:   TypeEnemy,
+				Radius: math.Max(z.Width, z.Height) / 2,
+			},
+			Boss:       boss,
+			Name:       z.Name,
+			Offset:     vector.New(z.X, z.Y),
+			Hitbox:     &Hitbox{Width: z.Width, Height: z.Height},
+			Multiplier: z.Multiplier,
+		}
+		boss.Zones = append(boss.Zones, zone)
+	}
+	boss.placeZones()
+	boss.enterPhase(0)
+	boss.phaseChanged = false
+
+	return boss
+}
+
+// Update moves the boss, switching phase once its health falls far enough
+func (b *Boss) Update(dt float64) error {
+	b.Time += dt
+	b.Defence.Update(dt)
+	if next := b.phaseFor(b.Health.GetPercentage()); next != b.Phase {
+		b.enterPhase(next)
+	}
+	if b.Weapon != nil {
+		b.Weapon.Update(dt)
+	}
+
+	world := b.World
+	if world == nil {
+		world = &World{Width: b.screenWidth, Height: b.screenHeight}
+	}
+	b.Velocity = b.Behaviour.Velocity(&b.Enemy, world, dt)
+	b.Position = b.Position.Add(b.Velocity.Mul(dt))
+
+	// Bosses stay in the top part of the screen until destroyed
+	halfWidth := b.Visual.Width / 2
+	b.Position.X = math.Max(halfWidth, math.Min(b.screenWidth-halfWidth, b.Position.X))
+	b.Position.Y = math.Min(b.screenHeight*0.4, b.Position.Y)
+
+	b.placeZones()
+	return nil
+}
+
+// phaseFor returns the last phase whose threshold fraction has been reached
+func (b *Boss) phaseFor(fraction float64) int {
+	phase := 0
+	for i, p := range b.Phases {
+		if fraction <= p.Threshold {
+			phase = i
+		}
+	}
+	return phase
+}
+
+// enterPhase switches to phase i's movement and weapon
+func (b *Boss) enterPhase(i int) {
+	p := b.Phases[i]
+	b.Phase = i
+	b.phaseChanged = true
+
+	b.MovePattern = ParseMovePattern(p.Movement)
+	b.Behaviour = NewBehaviour(b.MovePattern)
+	if strafe, ok := b.Behaviour.(*Strafe); ok {
+		// A boss holds its line rather than flying off
+		strafe.Hold = math.Inf(1)
+	}
+
+	b.Weapon = nil
+	if p.FirePattern != config.FireNone {
+		b.Weapon = &Weapon{
+			Damage:      p.BulletDamage,
+			FireRate:    p.FireRate,
+			BulletSpeed: p.BulletSpeed,
+			Pattern:     ParseFirePattern(p.FirePattern),
+			BurstCount:  p.BurstCount,
+		}
+	}
+}
+
+// PhaseChanged reports whether the boss has changed phase since the last call
+func (b *Boss) PhaseChanged() bool {
+	changed := b.phaseChanged
+	b.phaseChanged = false
+	return changed
+}
+
+// TakeDamage hits the boss through its defence, destroying it and its
+// zones at zero health
+func (b *Boss) TakeDamage(hit Damage) DamageEvent {
+	event := b.Health.Take(hit, b.Defence)
+	if b.Health.IsDead() {
+		b.SetActive(false)
+	}
+	return event
+}
+
+// SetActive activates or deactivates the boss and all of its zones
+func (b *Boss) SetActive(active bool) {
+	b.Active = active
+	for _, z := range b.Zones {
+		z.Active = active
+	}
+}
+
+func (b *Boss) placeZones() {
+	for _, z := range b.Zones {
+		z.Position = b.Position.Add(z.Offset)
+	}
+}
+
+// Submit queues the boss for drawing
+func (b *Boss) Submit(q *render.Queue) {
+	q.Submit(render.LayerGameplay, ZBoss, b)
+}
+
+// Draw draws each zone, weak points brighter and armour darker
+func (b *Boss) Draw(screen *ebiten.Image) {
+	for _, z := range b.Zones {
+		clr := b.Visual.Color
+		switch {
+		case z.Multiplier > 1:
+			clr = color.RGBA{R: 255, G: 220, B: 80, A: 255}
+		case z.Multiplier < 1:
+			clr = color.RGBA{R: 90, G: 90, B: 110, A: 255}
+		}
+
+		render.FillRect(screen, z.Position.X-z.Hitbox.Width/2, z.Position.Y-z.Hitbox.Height/2, z.Hitbox.Width, z.Hitbox.Height, clr)
+	}
+}
+
+// Update does nothing; zones are moved by their boss
+func (z *BossZone) Update(dt float64) error {
+	return nil
+}
+
+// GetHitbox returns the zone's collision box
+func (z *BossZone) GetHitbox() *Hitbox {
+	return z.Hitbox
+}
+
+// TakeDamage passes the hit on to the boss, scaled by the zone's multiplier
+func (z *BossZone) TakeDamage(hit Damage) DamageEvent {
+	hit.Amount = int(math.Round(float64(hit.Amount) * z.Multiplier))
+	hit.Rate *= z.Multiplier
+	return z.Boss.TakeDamage(hit)
+}
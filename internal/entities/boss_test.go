@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+// testBoss returns the default boss without its shield and armour, so
+// damage lands on health as dealt
+func testBoss(t *testing.T) *Boss {
+	t.Helper()
+	cfg, ok := config.Default().Boss("dreadnought")
+	if !ok {
+		t.Fatal("Expected the default dreadnought boss")
+	}
+	cfg.Defence = config.DefenceConfig{}
+	return NewBoss(cfg, 400, 100, 800, 600)
+}
+
+func TestBossZonesScaleDamage(t *testing.T) {
+	boss := testBoss(t)
+
+	for _, zone := range boss.Zones {
+		before := boss.Health.Current
+		zone.TakeDamage(Damage{Amount: 10})
+		want := int(10 * zone.Multiplier)
+		if got := before - boss.Health.Current; got != want {
+			t.Errorf("Zone %s: expected %d damage, got %d", zone.Name, want, got)
+		}
+	}
+
+	zone := boss.Zones[0]
+	if want := boss.GetPosition().Add(zone.Offset); zone.GetPosition() != want {
+		t.Errorf("Expected zone %s at %v, got %v", zone.Name, want, zone.GetPosition())
+	}
+}
+
+func TestBossZonesScaleLaser(t *testing.T) {
+	boss := testBoss(t)
+	laser := NewLaser(60, 6)
+	laser.SetActive(true)
+
+	for _, zone := range boss.Zones {
+		before := boss.Health.Current
+		for i := 0; i < 60; i++ {
+			_ = laser.Update(1.0 / 60)
+			zone.TakeDamage(laser.Hit())
+		}
+		// Allow for floating point error in the last fraction
+		want := int(60 * zone.Multiplier)
+		if got := before - boss.Health.Current; got < want-1 || got > want {
+			t.Errorf("Zone %s: expected about %d damage over one second, got %d", zone.Name, want, got)
+		}
+	}
+}
+
+func TestBossPhasesFollowHealth(t *testing.T) {
+	boss := testBoss(t)
+	if boss.PhaseChanged() {
+		t.Error("A new boss should not report a phase change")
+	}
+
+	for i, phase := range boss.Phases[1:] {
+		boss.Health.Current = int(float64(boss.Health.Maximum) * phase.Threshold)
+		_ = boss.Update(1.0 / 60)
+
+		if boss.Phase != i+1 {
+			t.Errorf("Expected phase %d at %.0f%% health, got %d", i+1, phase.Threshold*100, boss.Phase)
+		}
+		if !boss.PhaseChanged() {
+			t.Errorf("Expected phase %d to be reported", i+1)
+		}
+		if boss.MovePattern != ParseMovePattern(phase.Movement) || boss.Weapon.Pattern != ParseFirePattern(phase.FirePattern) {
+			t.Errorf("Phase %d did not switch movement and weapon", i+1)
+		}
+	}
+}
+
+func TestBossStaysOnScreen(t *testing.T) {
+	boss := testBoss(t)
+	boss.World = &World{Player: vector.New(0, 500), HasPlayer: true, Width: 800, Height: 600}
+	boss.enterPhase(1) // strafe, tracking the player into the corner
+
+	for i := 0; i < 600; i++ {
+		_ = boss.Update(1.0 / 60)
+	}
+
+	pos := boss.GetPosition()
+	if pos.X < boss.Visual.Width/2 || pos.Y > 600*0.4 {
+		t.Errorf("Expected the boss to stay in the top of the screen, at %v", pos)
+	}
+	if !boss.IsActive() {
+		t.Error("Expected the boss to stay in play")
+	}
+}
+
+func TestBossDestroyedWithZones(t *testing.T) {
+	boss := testBoss(t)
+	boss.Zones[0].TakeDamage(Damage{Amount: boss.Health.Maximum})
+
+	if boss.IsActive() {
+		t.Error("Expected the boss to be destroyed")
+	}
+	for _, zone := range boss.Zones {
+		if zone.IsActive() {
+			t.Errorf("Expected zone %s to be destroyed with the boss", zone.Name)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package entities
 import (
 	"image/color"
 
+	"github.com/EchoSingh/space-shooter/internal/render"
 	"github.com/EchoSingh/space-shooter/pkg/vector"
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -15,14 +16,31 @@ const (
 	OwnerEnemy
 )
 
+// FastMoverSpeed is the speed above which bullets use swept collision
+// so they can't tunnel through small targets between frames
+const FastMoverSpeed = 300.0
+
 // Bullet represents a projectile
 type Bullet struct {
 	BaseEntity
-	Visual   *Visual
-	Damage   int
-	Owner    BulletOwner
-	LifeTime float64
-	MaxLife  float64
+	Visual       *Visual
+	Animator     *Animator
+	Damage       int
+	DamageType   DamageType
+	Owner        BulletOwner
+	LifeTime     float64
+	MaxLife      float64
+	PrevPosition vector.Vector2
+	FastMover    bool
+	Projectile   ProjectileType
+
+	// Homing, used by missiles
+	Target   Entity
+	TurnRate float64
+
+	// Grazing, tracked on enemy bullets passing close to the player
+	Grazing bool
+	Grazed  bool
 
 	screenWidth  float64
 	screenHeight float64
@@ -51,6 +69,8 @@ func NewBullet(x, y float64, velocity vector.Vector2, damage int, owner BulletOw
 		Damage:       damage,
 		Owner:        owner,
 		MaxLife:      3.0,
+		PrevPosition: vector.New(x, y),
+		FastMover:    velocity.Length() > FastMoverSpeed,
 		screenWidth:  screenWidth,
 		screenHeight: screenHeight,
 	}
@@ -60,8 +80,16 @@ func NewBullet(x, y float64, velocity vector.Vector2, damage int, owner BulletOw
 func (b *Bullet) Update(dt float64) error {
 	b.LifeTime += dt
 
+	if b.Projectile == ProjectileMissile {
+		b.steer(dt)
+	}
+
 	// Update position
+	b.PrevPosition = b.Position
 	b.Position = b.Position.Add(b.Velocity.Mul(dt))
+	if b.Animator != nil {
+		b.Animator.Update(dt)
+	}
 
 	// Deactivate if off screen or too old
 	if b.LifeTime > b.MaxLife {
@@ -77,16 +105,18 @@ func (b *Bullet) Update(dt float64) error {
 	return nil
 }
 
+// Submit queues the bullet for drawing
+func (b *Bullet) Submit(q *render.Queue) {
+	q.Submit(render.LayerGameplay, ZBullets, b)
+}
+
 // Draw draws the bullet
 func (b *Bullet) Draw(screen *ebiten.Image) {
-	x, y := b.Position.X, b.Position.Y
-	w, h := b.Visual.Width/2, b.Visual.Height/2
-
-	img := ebiten.NewImage(int(b.Visual.Width), int(b.Visual.Height))
-	img.Fill(b.Visual.Color)
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(x-w, y-h)
-	screen.DrawImage(img, op)
+	if b.Animator != nil {
+		b.Animator.Draw(screen, b.Position, b.Visual)
+		return
+	}
+	render.FillRectRotated(screen, b.Position.X, b.Position.Y, b.Visual.Width, b.Visual.Height, b.Visual.Angle, b.Visual.Color)
 }
 
 // OnCollision handles collision
@@ -94,11 +124,26 @@ func (b *Bullet) OnCollision(other Entity) {
 	b.Active = false
 }
 
+// IsFastMover reports whether the bullet needs swept collision
+func (b *Bullet) IsFastMover() bool {
+	return b.FastMover
+}
+
+// GetPreviousPosition returns the bullet's position before its last update
+func (b *Bullet) GetPreviousPosition() vector.Vector2 {
+	return b.PrevPosition
+}
+
 // GetDamage returns the bullet's damage
 func (b *Bullet) GetDamage() int {
 	return b.Damage
 }
 
+// Hit returns the damage the bullet deals
+func (b *Bullet) Hit() Damage {
+	return Damage{Amount: b.Damage, Type: b.DamageType}
+}
+
 // GetOwner returns who fired the bullet
 func (b *Bullet) GetOwner() BulletOwner {
 	return b.Owner
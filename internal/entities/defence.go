@@ -0,0 +1,172 @@
+package entities
+
+import (
+	"math"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+)
+
+// DamageType is the kind of damage a hit deals
+type DamageType int
+
+const (
+	DamageKinetic DamageType = iota
+	DamageEnergy
+	DamageExplosive
+	damageTypeCount
+)
+
+// DamageTypes lists every damage type
+var DamageTypes = []DamageType{DamageKinetic, DamageEnergy, DamageExplosive}
+
+// String returns the damage type's name as used in the config file
+func (t DamageType) String() string {
+	switch t {
+	case DamageKinetic:
+		return config.DamageKinetic
+	case DamageEnergy:
+		return config.DamageEnergy
+	case DamageExplosive:
+		return config.DamageExplosive
+	}
+	return "unknown"
+}
+
+// Damage is a single hit. Continuous damage, like a beam's, is spread
+// over time instead: Rate is its damage per second and Duration how long
+// this hit lasted.
+type Damage struct {
+	Amount   int
+	Type     DamageType
+	Rate     float64
+	Duration float64
+}
+
+// Continuous reports whether the hit is spread over time
+func (d Damage) Continuous() bool {
+	return d.Duration > 0
+}
+
+// DamageEvent reports how a hit was taken: how much each layer of
+// defence soaked up and how much reached health. Resisted is negative
+// when a weakness added damage.
+type DamageEvent struct {
+	Damage
+	Resisted int
+	Armoured int
+	Shielded int
+	Dealt    int
+
+	// dealt is the exact damage left for health, which for continuous
+	// hits is usually a fraction
+	dealt float64
+}
+
+// Absorbed returns the damage stopped before it reached health
+func (e DamageEvent) Absorbed() int {
+	return e.Resisted + e.Armoured + e.Shielded
+}
+
+// Defence protects health with resistances, armour and a recharging shield
+type Defence struct {
+	Shield      float64
+	MaxShield   float64
+	ShieldRegen float64
+	ShieldDelay float64
+	Armour      int
+	Resistances [damageTypeCount]float64
+
+	sinceHit float64
+}
+
+// NewDefence creates a defence from its config with the shield fully charged
+func NewDefence(cfg config.DefenceConfig) *Defence {
+	d := &Defence{
+		Shield:      cfg.Shield,
+		MaxShield:   cfg.Shield,
+		ShieldRegen: cfg.ShieldRegen,
+		ShieldDelay: cfg.ShieldDelay,
+		Armour:      cfg.Armour,
+	}
+	for _, t := range DamageTypes {
+		d.Resistances[t] = cfg.Resistances[t.String()]
+	}
+	return d
+}
+
+// Update recharges the shield once it has gone long enough without a hit
+func (d *Defence) Update(dt float64) {
+	d.sinceHit += dt
+	if d.sinceHit >= d.ShieldDelay {
+		d.Shield = math.Min(d.MaxShield, d.Shield+d.ShieldRegen*dt)
+	}
+}
+
+// Absorb runs a hit through resistance, armour and shield, returning
+// what is left over for health in Dealt. A single hit is rounded to whole
+// damage; a continuous one is worked out on its rate and kept exact, so
+// small ticks are still resisted and armoured in proportion.
+func (d *Defence) Absorb(hit Damage) DamageEvent {
+	event := DamageEvent{Damage: hit}
+	d.sinceHit = 0
+
+	base, scale := float64(hit.Amount), 1.0
+	if hit.Continuous() {
+		base, scale = hit.Rate, hit.Duration
+	}
+
+	amount := base * (1 - d.Resistances[hit.Type])
+	if !hit.Continuous() {
+		amount = math.Round(amount)
+	}
+	resisted := base - amount
+
+	// Armour blunts a hit but never stops it outright
+	armoured := 0.0
+	if amount > 0 {
+		armoured = math.Max(0, math.Min(float64(d.Armour), amount-1))
+		amount -= armoured
+	}
+	resisted, armoured, amount = resisted*scale, armoured*scale, amount*scale
+
+	shield := math.Floor(d.Shield)
+	if hit.Continuous() {
+		shield = d.Shield
+	}
+	shielded := math.Min(amount, shield)
+	d.Shield -= shielded
+
+	event.Resisted = int(math.Round(resisted))
+	event.Armoured = int(math.Round(armoured))
+	event.Shielded = int(math.Round(shielded))
+	event.dealt = amount - shielded
+	event.Dealt = int(math.Round(event.dealt))
+	return event
+}
+
+// ShieldFraction returns the shield's charge from 0 to 1
+func (d *Defence) ShieldFraction() float64 {
+	if d.MaxShield == 0 {
+		return 0
+	}
+	return d.Shield / d.MaxShield
+}
+
+// Take applies a hit to health through an optional defence. Fractions
+// of damage left by continuous hits are carried over until they add up
+// to a whole point, which is what Dealt then reports.
+func (h *Health) Take(hit Damage, defence *Defence) DamageEvent {
+	event := DamageEvent{Damage: hit, Dealt: hit.Amount, dealt: float64(hit.Amount)}
+	if hit.Continuous() {
+		event.dealt = hit.Rate * hit.Duration
+	}
+	if defence != nil {
+		event = defence.Absorb(hit)
+	}
+
+	h.carry += event.dealt
+	event.Dealt = int(h.carry)
+	h.carry -= float64(event.Dealt)
+	h.Damage(event.Dealt)
+	return event
+}
@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+)
+
+func TestResistancesScaleDamage(t *testing.T) {
+	defence := NewDefence(config.DefenceConfig{
+		Resistances: map[string]float64{config.DamageKinetic: 0.25, config.DamageExplosive: -0.5},
+	})
+
+	tests := []struct {
+		hit      Damage
+		resisted int
+		dealt    int
+	}{
+		{Damage{Amount: 20, Type: DamageKinetic}, 5, 15},
+		{Damage{Amount: 20, Type: DamageEnergy}, 0, 20},
+		{Damage{Amount: 20, Type: DamageExplosive}, -10, 30},
+	}
+	for _, tt := range tests {
+		event := defence.Absorb(tt.hit)
+		if event.Resisted != tt.resisted || event.Dealt != tt.dealt {
+			t.Errorf("%s: expected %d resisted and %d dealt, got %+v", tt.hit.Type, tt.resisted, tt.dealt, event)
+		}
+	}
+}
+
+func TestArmourNeverStopsAHit(t *testing.T) {
+	defence := NewDefence(config.DefenceConfig{Armour: 3})
+
+	if event := defence.Absorb(Damage{Amount: 10}); event.Armoured != 3 || event.Dealt != 7 {
+		t.Errorf("Expected armour to take 3 of 10, got %+v", event)
+	}
+	if event := defence.Absorb(Damage{Amount: 2}); event.Dealt != 1 {
+		t.Errorf("Expected a weak hit to still deal 1, got %+v", event)
+	}
+}
+
+func TestShieldAbsorbsAndRecharges(t *testing.T) {
+	defence := NewDefence(config.DefenceConfig{Shield: 20, ShieldRegen: 10, ShieldDelay: 2})
+	health := NewHealth(100)
+
+	event := health.Take(Damage{Amount: 30}, defence)
+	if event.Shielded != 20 || event.Dealt != 10 || health.Current != 90 {
+		t.Errorf("Expected the shield to take 20 of 30, got %+v with %d health", event, health.Current)
+	}
+	if event.Absorbed() != 20 {
+		t.Errorf("Expected 20 absorbed, got %d", event.Absorbed())
+	}
+
+	// Nothing comes back until the delay has passed
+	for i := 0; i < 60; i++ {
+		defence.Update(1.0 / 60)
+	}
+	if defence.Shield != 0 {
+		t.Errorf("Expected no recharge within the delay, got %g", defence.Shield)
+	}
+
+	for i := 0; i < 240; i++ {
+		defence.Update(1.0 / 60)
+	}
+	if defence.Shield != defence.MaxShield || defence.ShieldFraction() != 1 {
+		t.Errorf("Expected a full shield after recharging, got %g", defence.Shield)
+	}
+}
+
+func TestTakeWithoutDefence(t *testing.T) {
+	health := NewHealth(50)
+
+	event := health.Take(Damage{Amount: 20, Type: DamageEnergy}, nil)
+	if event.Dealt != 20 || event.Absorbed() != 0 || health.Current != 30 {
+		t.Errorf("Expected the full hit on health, got %+v with %d health", event, health.Current)
+	}
+}
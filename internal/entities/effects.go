@@ -0,0 +1,71 @@
+package entities
+
+// Effect is a timed power-up effect on the player
+type Effect int
+
+const (
+	EffectShield Effect = iota
+	EffectRapidFire
+	EffectWeapon
+	EffectScore
+	effectCount
+)
+
+// TimedEffects lists every timed effect
+var TimedEffects = []Effect{EffectShield, EffectRapidFire, EffectWeapon, EffectScore}
+
+// String returns the effect's HUD label
+func (e Effect) String() string {
+	switch e {
+	case EffectShield:
+		return "SHIELD"
+	case EffectRapidFire:
+		return "RAPID FIRE"
+	case EffectWeapon:
+		return "WEAPON"
+	case EffectScore:
+		return "SCORE BONUS"
+	}
+	return "UNKNOWN"
+}
+
+// EffectTimers tracks how long each timed effect has left
+type EffectTimers struct {
+	remaining [effectCount]float64
+}
+
+// Start activates an effect for duration seconds, refreshing it if already active
+func (t *EffectTimers) Start(effect Effect, duration float64) {
+	t.remaining[effect] = duration
+}
+
+// Stop ends an effect early
+func (t *EffectTimers) Stop(effect Effect) {
+	t.remaining[effect] = 0
+}
+
+// Active reports whether an effect is running
+func (t *EffectTimers) Active(effect Effect) bool {
+	return t.remaining[effect] > 0
+}
+
+// Remaining returns the seconds left on an effect
+func (t *EffectTimers) Remaining(effect Effect) float64 {
+	return t.remaining[effect]
+}
+
+// Update counts the timers down and reports whether any effect expired
+func (t *EffectTimers) Update(dt float64) bool {
+	expired := false
+	for i, r := range t.remaining {
+		if r <= 0 {
+			continue
+		}
+		t.remaining[i] = r - dt
+		if t.remaining[i] <= 0 {
+			t.remaining[i] = 0
+			expired = true
+		}
+	}
+	return expired
+}
@@ -2,9 +2,10 @@ package entities
 
 import (
 	"image/color"
-	"math"
-	"math/rand"
 
+	"github.com/EchoSingh/space-shooter/internal/assets"
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/render"
 	"github.com/EchoSingh/space-shooter/pkg/vector"
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -19,22 +20,56 @@ const (
 	EnemyShooter
 )
 
+// EnemyTypes lists every enemy type
+var EnemyTypes = []EnemyType{EnemyBasic, EnemyFast, EnemyTank, EnemyShooter}
+
+// String returns the enemy type's name as used in the config file
+func (t EnemyType) String() string {
+	switch t {
+	case EnemyBasic:
+		return config.EnemyBasic
+	case EnemyFast:
+		return config.EnemyFast
+	case EnemyTank:
+		return config.EnemyTank
+	case EnemyShooter:
+		return config.EnemyShooter
+	}
+	return "unknown"
+}
+
+// ParseEnemyType converts a config enemy type name
+func ParseEnemyType(name string) (EnemyType, bool) {
+	for _, t := range EnemyTypes {
+		if t.String() == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
 // Enemy represents an enemy ship
 type Enemy struct {
 	BaseEntity
 	Health      *Health
+	Defence     *Defence
 	Visual      *Visual
+	Hitbox      *Hitbox
+	Animator    *Animator
+	Weapon      *Weapon
 	EnemyType   EnemyType
 	Speed       float64
 	ScoreValue  int
 	MovePattern MovePattern
+	Behaviour   Behaviour
+	World       *World
 	Time        float64
 
 	screenWidth  float64
 	screenHeight float64
 }
 
-// MovePattern defines enemy movement behavior
+// MovePattern names a standard movement Behaviour
 type MovePattern int
 
 const (
@@ -42,10 +77,15 @@ const (
 	PatternSine
 	PatternZigZag
 	PatternSeek
+	PatternStrafe
+	PatternOrbit
+	PatternDive
+	PatternHover
+	PatternFormation
 )
 
-// NewEnemy creates a new enemy
-func NewEnemy(enemyType EnemyType, x, y, screenWidth, screenHeight float64) *Enemy {
+// NewEnemy creates a new enemy with the given stats
+func NewEnemy(enemyType EnemyType, x, y, screenWidth, screenHeight float64, stats config.EnemyTypeConfig) *Enemy {
 	enemy := &Enemy{
 		BaseEntity: BaseEntity{
 			Position: vector.New(x, y),
@@ -53,18 +93,19 @@ func NewEnemy(enemyType EnemyType, x, y, screenWidth, screenHeight float64) *Ene
 			Active:   true,
 			Type:     TypeEnemy,
 		},
+		Health:       NewHealth(stats.Health),
+		Defence:      NewDefence(stats.Defence),
 		EnemyType:    enemyType,
+		Speed:        stats.Speed,
+		ScoreValue:   stats.Score,
 		screenWidth:  screenWidth,
 		screenHeight: screenHeight,
 	}
 
-	// Configure based on type
+	// Configure appearance based on type
 	switch enemyType {
 	case EnemyBasic:
-		enemy.Health = NewHealth(20)
-		enemy.Speed = 100
 		enemy.Radius = 12
-		enemy.ScoreValue = 10
 		enemy.MovePattern = PatternStraight
 		enemy.Visual = &Visual{
 			Color:  color.RGBA{R: 255, G: 100, B: 100, A: 255},
@@ -72,10 +113,7 @@ func NewEnemy(enemyType EnemyType, x, y, screenWidth, screenHeight float64) *Ene
 			Height: 24,
 		}
 	case EnemyFast:
-		enemy.Health = NewHealth(10)
-		enemy.Speed = 200
 		enemy.Radius = 10
-		enemy.ScoreValue = 15
 		enemy.MovePattern = PatternZigZag
 		enemy.Visual = &Visual{
 			Color:  color.RGBA{R: 255, G: 150, B: 50, A: 255},
@@ -83,10 +121,7 @@ func NewEnemy(enemyType EnemyType, x, y, screenWidth, screenHeight float64) *Ene
 			Height: 20,
 		}
 	case EnemyTank:
-		enemy.Health = NewHealth(50)
-		enemy.Speed = 50
 		enemy.Radius = 20
-		enemy.ScoreValue = 25
 		enemy.MovePattern = PatternStraight
 		enemy.Visual = &Visual{
 			Color:  color.RGBA{R: 150, G: 50, B: 50, A: 255},
@@ -94,10 +129,7 @@ func NewEnemy(enemyType EnemyType, x, y, screenWidth, screenHeight float64) *Ene
 			Height: 40,
 		}
 	case EnemyShooter:
-		enemy.Health = NewHealth(30)
-		enemy.Speed = 80
 		enemy.Radius = 15
-		enemy.ScoreValue = 20
 		enemy.MovePattern = PatternSine
 		enemy.Visual = &Visual{
 			Color:  color.RGBA{R: 200, G: 50, B: 200, A: 255},
@@ -105,6 +137,26 @@ func NewEnemy(enemyType EnemyType, x, y, screenWidth, screenHeight float64) *Ene
 			Height: 30,
 		}
 	}
+	if stats.Movement != "" {
+		enemy.MovePattern = ParseMovePattern(stats.Movement)
+	}
+	enemy.Behaviour = NewBehaviour(enemy.MovePattern)
+
+	enemy.Hitbox = &Hitbox{
+		Width:  enemy.Visual.Width,
+		Height: enemy.Visual.Height,
+	}
+
+	// Arm enemies that have a fire pattern
+	if stats.FirePattern != config.FireNone {
+		enemy.Weapon = &Weapon{
+			Damage:      stats.BulletDamage,
+			FireRate:    stats.FireRate,
+			BulletSpeed: stats.BulletSpeed,
+			Pattern:     ParseFirePattern(stats.FirePattern),
+			BurstCount:  stats.BurstCount,
+		}
+	}
 
 	return enemy
 }
@@ -112,53 +164,87 @@ func NewEnemy(enemyType EnemyType, x, y, screenWidth, screenHeight float64) *Ene
 // Update updates the enemy
 func (e *Enemy) Update(dt float64) error {
 	e.Time += dt
+	e.Defence.Update(dt)
+	if e.Weapon != nil {
+		e.Weapon.Update(dt)
+	}
 
-	// Update movement based on pattern
-	switch e.MovePattern {
-	case PatternStraight:
-		e.Velocity = vector.New(0, e.Speed)
-	case PatternSine:
-		e.Velocity = vector.New(
-			math.Sin(e.Time*2)*100,
-			e.Speed,
-		)
-	case PatternZigZag:
-		zigzag := math.Floor(e.Time*2) / 2
-		direction := 1.0
-		if int(zigzag)%2 == 0 {
-			direction = -1.0
-		}
-		e.Velocity = vector.New(direction*150, e.Speed)
-	case PatternSeek:
-		// This would seek the player (needs player reference)
-		e.Velocity = vector.New(0, e.Speed)
+	// Let the behaviour steer, falling back to a world of just the screen
+	world := e.World
+	if world == nil {
+		world = &World{Width: e.screenWidth, Height: e.screenHeight}
 	}
+	e.Velocity = e.Behaviour.Velocity(e, world, dt)
 
 	// Update position
 	e.Position = e.Position.Add(e.Velocity.Mul(dt))
 
-	// Deactivate if off screen
+	// Deactivate once off screen, leaving enemies that are still flying
+	// in from the sides alone
 	if e.Position.Y > e.screenHeight+50 {
 		e.Active = false
 	}
-	if e.Position.X < -50 || e.Position.X > e.screenWidth+50 {
+	if (e.Position.X < -50 && e.Velocity.X <= 0) || (e.Position.X > e.screenWidth+50 && e.Velocity.X >= 0) {
 		e.Active = false
 	}
 
+	// Bank with sideways movement, colliding as the sprite shows
+	if e.Animator != nil {
+		e.Animator.Bank(e.Velocity.X)
+		e.Animator.Update(dt)
+		e.Animator.Fit(e.Hitbox, e.Visual)
+	}
+
 	return nil
 }
 
+// Submit queues the enemy for drawing
+func (e *Enemy) Submit(q *render.Queue) {
+	q.Submit(render.LayerGameplay, ZEnemies, e)
+}
+
 // Draw draws the enemy
 func (e *Enemy) Draw(screen *ebiten.Image) {
-	x, y := float32(e.Position.X), float32(e.Position.Y)
-	w := float32(e.Visual.Width / 2)
-
-	// Draw enemy as simple square
-	img := ebiten.NewImage(int(w*2), int(w*2))
-	img.Fill(e.Visual.Color)
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(float64(x-w), float64(y-w))
-	screen.DrawImage(img, op)
+	x, y := e.Position.X, e.Position.Y
+	w, h := e.Visual.Width, e.Visual.Height
+
+	// Draw the sprite, or a simple square without one
+	if e.Animator != nil {
+		e.Animator.Draw(screen, e.Position, e.Visual)
+	} else {
+		render.FillRectRotated(screen, x, y, w, h, e.Visual.Angle, e.Visual.Color)
+	}
+
+	// Draw the shield over it, fading as it drains
+	if e.Defence.Shield > 0 {
+		shield := color.RGBA{R: 80, G: 160, B: 255, A: uint8(30 + 70*e.Defence.ShieldFraction())}
+		render.FillRectRotated(screen, x, y, w+8, h+8, e.Visual.Angle, shield)
+	}
+}
+
+// CanFire reports whether the enemy is armed, reloaded and on screen
+func (e *Enemy) CanFire() bool {
+	if e.Weapon == nil || !e.Weapon.CanFire() {
+		return false
+	}
+	return e.Position.Y > 0 && e.Position.Y < e.screenHeight &&
+		e.Position.X > 0 && e.Position.X < e.screenWidth
+}
+
+// FireWeapon fires at target and returns the velocity of each bullet
+func (e *Enemy) FireWeapon(target vector.Vector2) []vector.Vector2 {
+	e.Weapon.Fire()
+	dirs := e.Weapon.Directions(e.Position, target)
+	for i := range dirs {
+		dirs[i] = dirs[i].Mul(e.Weapon.BulletSpeed)
+	}
+	return dirs
+}
+
+// GetHitbox returns the enemy's collision box
+func (e *Enemy) GetHitbox() *Hitbox {
+	e.Hitbox.Angle = e.Visual.Angle
+	return e.Hitbox
 }
 
 // OnCollision handles collision
@@ -171,21 +257,14 @@ func (e *Enemy) OnCollision(other Entity) {
 	}
 }
 
-// TakeDamage damages the enemy
-func (e *Enemy) TakeDamage(amount int) {
-	e.Health.Damage(amount)
+// TakeDamage hits the enemy through its defence
+func (e *Enemy) TakeDamage(hit Damage) DamageEvent {
+	event := e.Health.Take(hit, e.Defence)
 	if e.Health.IsDead() {
 		e.Active = false
 	}
-}
-
-// SpawnRandom spawns a random enemy
-func SpawnRandom(screenWidth, screenHeight float64) *Enemy {
-	enemyTypes := []EnemyType{EnemyBasic, EnemyFast, EnemyTank, EnemyShooter}
-	enemyType := enemyTypes[rand.Intn(len(enemyTypes))]
-
-	x := rand.Float64() * screenWidth
-	y := -30.0
-
-	return NewEnemy(enemyType, x, y, screenWidth, screenHeight)
+	if e.Animator != nil && event.Dealt > 0 {
+		e.Animator.Trigger(assets.AnimHit)
+	}
+	return event
 }
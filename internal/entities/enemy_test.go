@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+var testShooterEnemy, _ = config.Default().Enemies.EnemyType(config.EnemyShooter)
+
+func TestShooterIsArmed(t *testing.T) {
+	if e := NewEnemy(EnemyBasic, 100, 100, 800, 600, testBasicEnemy); e.Weapon != nil {
+		t.Error("Basic enemy should not be armed")
+	}
+
+	e := NewEnemy(EnemyShooter, 100, 100, 800, 600, testShooterEnemy)
+	if e.Weapon == nil {
+		t.Fatal("Shooter enemy should be armed")
+	}
+	if e.Weapon.Pattern != FireAimed {
+		t.Errorf("Expected aimed fire pattern, got %v", e.Weapon.Pattern)
+	}
+}
+
+func TestEnemyFiresOnlyOnScreen(t *testing.T) {
+	e := NewEnemy(EnemyShooter, 100, -50, 800, 600, testShooterEnemy)
+	e.Weapon.CurrentTime = e.Weapon.FireRate
+
+	if e.CanFire() {
+		t.Error("Enemy should not fire before it is on screen")
+	}
+
+	e.Position = vector.New(100, 100)
+	if !e.CanFire() {
+		t.Fatal("Reloaded enemy on screen should fire")
+	}
+
+	vels := e.FireWeapon(vector.New(100, 500))
+	if len(vels) != 1 {
+		t.Fatalf("Expected 1 bullet, got %d", len(vels))
+	}
+	if vels[0].Y <= 0 || math.Abs(vels[0].Length()-testShooterEnemy.BulletSpeed) > 1e-9 {
+		t.Errorf("Expected bullet aimed down at bullet speed, got %v", vels[0])
+	}
+	if e.CanFire() {
+		t.Error("Enemy should reload after firing")
+	}
+}
+
+func TestWeaponDirections(t *testing.T) {
+	origin := vector.New(0, 0)
+	target := vector.New(3, 4)
+
+	aimed := (&Weapon{Pattern: FireAimed}).Directions(origin, target)
+	if len(aimed) != 1 || aimed[0].Distance(vector.New(0.6, 0.8)) > 1e-9 {
+		t.Errorf("Expected aimed direction (0.6, 0.8), got %v", aimed)
+	}
+
+	down := (&Weapon{Pattern: FireDown}).Directions(origin, target)
+	if len(down) != 1 || down[0] != vector.New(0, 1) {
+		t.Errorf("Expected straight down, got %v", down)
+	}
+
+	spread := (&Weapon{BurstCount: 5, Spread: math.Pi / 4}).Directions(origin, target)
+	if len(spread) != 5 {
+		t.Fatalf("Expected 5 spread bullets, got %d", len(spread))
+	}
+	if spread[2].Distance(vector.New(0, -1)) > 1e-9 || spread[0].X == 0 || math.Abs(spread[0].X+spread[4].X) > 1e-9 {
+		t.Errorf("Expected a symmetric fan around straight up, got %v", spread)
+	}
+
+	radial := (&Weapon{Pattern: FireRadial, BurstCount: 8}).Directions(origin, target)
+	if len(radial) != 8 {
+		t.Fatalf("Expected 8 radial bullets, got %d", len(radial))
+	}
+	var sum vector.Vector2
+	for _, d := range radial {
+		sum = sum.Add(d)
+	}
+	if sum.Length() > 1e-9 {
+		t.Errorf("Radial bullets should be evenly spaced, sum %v", sum)
+	}
+}
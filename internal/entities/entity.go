@@ -2,7 +2,10 @@ package entities
 
 import (
 	"image/color"
+	"math"
 
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/render"
 	"github.com/EchoSingh/space-shooter/pkg/vector"
 )
 
@@ -67,6 +70,23 @@ type Drawable interface {
 	Draw(screen interface{})
 }
 
+// Renderable is an entity that queues itself for drawing
+type Renderable interface {
+	Entity
+	Submit(q *render.Queue)
+}
+
+// Depths of entities within the gameplay layer, back to front
+const (
+	ZParticles = iota
+	ZWrecks
+	ZBullets
+	ZEnemies
+	ZBoss
+	ZPowerUps
+	ZPlayer
+)
+
 // Collidable is an interface for entities that can collide
 type Collidable interface {
 	Entity
@@ -77,6 +97,8 @@ type Collidable interface {
 type Health struct {
 	Current int
 	Maximum int
+
+	carry float64
 }
 
 func NewHealth(max int) *Health {
@@ -119,14 +141,33 @@ type Visual struct {
 	Angle  float64
 }
 
-// Weapon component
+// Hitbox component describing a collision outline relative to the
+// entity's position. Without Points it is a Width x Height box rotated
+// by Angle; with Points it is that convex polygon rotated by Angle.
+type Hitbox struct {
+	Width  float64
+	Height float64
+	Angle  float64
+	Points []vector.Vector2
+}
+
+// Weapon component.
+// Spread is the width of a straight volley's fan in radians, TurnRate
+// steers missiles and the laser deals DamagePerSecond along a beam of
+// BeamWidth.
 type Weapon struct {
-	Damage         int
-	FireRate       float64
-	BulletSpeed    float64
-	LastFireTime   float64
-	CurrentTime    float64
-	ProjectileType ProjectileType
+	Damage          int
+	FireRate        float64
+	BulletSpeed     float64
+	LastFireTime    float64
+	CurrentTime     float64
+	ProjectileType  ProjectileType
+	Pattern         FirePattern
+	BurstCount      int
+	Spread          float64
+	TurnRate        float64
+	DamagePerSecond float64
+	BeamWidth       float64
 }
 
 type ProjectileType int
@@ -138,6 +179,95 @@ const (
 	ProjectileSpread
 )
 
+// String returns the projectile type's name as used in the config file
+func (t ProjectileType) String() string {
+	switch t {
+	case ProjectileNormal:
+		return config.ProjectileNormal
+	case ProjectileLaser:
+		return config.ProjectileLaser
+	case ProjectileMissile:
+		return config.ProjectileMissile
+	case ProjectileSpread:
+		return config.ProjectileSpread
+	}
+	return "unknown"
+}
+
+// ParseProjectileType converts a config projectile name
+func ParseProjectileType(name string) ProjectileType {
+	switch name {
+	case config.ProjectileLaser:
+		return ProjectileLaser
+	case config.ProjectileMissile:
+		return ProjectileMissile
+	case config.ProjectileSpread:
+		return ProjectileSpread
+	}
+	return ProjectileNormal
+}
+
+// FirePattern decides which directions a weapon fires in
+type FirePattern int
+
+const (
+	FireStraight FirePattern = iota // straight up in a fan of BurstCount, as the player fires
+	FireAimed                       // at the target
+	FireDown                        // straight down
+	FireRadial                      // an evenly spaced ring of BurstCount bullets
+)
+
+// ParseFirePattern converts a config fire pattern name
+func ParseFirePattern(name string) FirePattern {
+	switch name {
+	case config.FireAimed:
+		return FireAimed
+	case config.FireDown:
+		return FireDown
+	case config.FireRadial:
+		return FireRadial
+	}
+	return FireStraight
+}
+
+// Directions returns a unit vector for each bullet fired from origin at target
+func (w *Weapon) Directions(origin, target vector.Vector2) []vector.Vector2 {
+	switch w.Pattern {
+	case FireAimed:
+		dir := target.Sub(origin).Normalize()
+		if dir == vector.Zero() {
+			dir = vector.New(0, 1)
+		}
+		return []vector.Vector2{dir}
+	case FireDown:
+		return []vector.Vector2{vector.New(0, 1)}
+	case FireRadial:
+		count := w.BurstCount
+		if count < 1 {
+			count = 1
+		}
+		dirs := make([]vector.Vector2, count)
+		for i := range dirs {
+			angle := math.Pi/2 + 2*math.Pi*float64(i)/float64(count)
+			dirs[i] = vector.New(math.Cos(angle), math.Sin(angle))
+		}
+		return dirs
+	}
+	return fan(vector.New(0, -1), w.BurstCount, w.Spread)
+}
+
+// fan returns count directions spread evenly across arc radians around dir
+func fan(dir vector.Vector2, count int, arc float64) []vector.Vector2 {
+	if count <= 1 {
+		return []vector.Vector2{dir}
+	}
+	dirs := make([]vector.Vector2, count)
+	for i := range dirs {
+		dirs[i] = dir.Rotate(-arc/2 + arc*float64(i)/float64(count-1))
+	}
+	return dirs
+}
+
 func (w *Weapon) CanFire() bool {
 	return w.CurrentTime-w.LastFireTime >= w.FireRate
 }
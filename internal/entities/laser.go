@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/EchoSingh/space-shooter/internal/render"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Laser is a continuous beam fired straight up from Position to the top
+// of the screen. It damages everything along its length each tick, as a
+// continuous hit lasting the tick, so each target's defences and health
+// deal with the fractions.
+type Laser struct {
+	BaseEntity
+	Visual          *Visual
+	DamagePerSecond float64
+
+	tick float64
+}
+
+// NewLaser creates an inactive laser beam
+func NewLaser(damagePerSecond, width float64) *Laser {
+	return &Laser{
+		BaseEntity: BaseEntity{
+			Type:   TypeBullet,
+			Radius: width / 2,
+		},
+		Visual: &Visual{
+			Color: color.RGBA{R: 120, G: 255, B: 220, A: 200},
+			Width: width,
+		},
+		DamagePerSecond: damagePerSecond,
+	}
+}
+
+// Aim moves the beam's origin
+func (l *Laser) Aim(origin vector.Vector2) {
+	l.Position = origin
+}
+
+// Update records how long the beam fires for this tick
+func (l *Laser) Update(dt float64) error {
+	l.tick = 0
+	if l.Active {
+		l.tick = dt
+	}
+	return nil
+}
+
+// Hit returns the energy damage the beam deals to each target this tick
+func (l *Laser) Hit() Damage {
+	return Damage{Type: DamageEnergy, Rate: l.DamagePerSecond, Duration: l.tick}
+}
+
+// TickDamage returns the damage the beam deals to each target this tick,
+// before defences
+func (l *Laser) TickDamage() float64 {
+	return l.DamagePerSecond * l.tick
+}
+
+// End returns the far end of the beam
+func (l *Laser) End() vector.Vector2 {
+	return vector.New(l.Position.X, 0)
+}
+
+// Submit queues the beam for drawing
+func (l *Laser) Submit(q *render.Queue) {
+	q.Submit(render.LayerGameplay, ZBullets, l)
+}
+
+// Draw draws the beam with a bright core
+func (l *Laser) Draw(screen *ebiten.Image) {
+	length := l.Position.Y - l.End().Y
+	width := l.Visual.Width
+	if length < 1 || width < 1 {
+		return
+	}
+
+	render.FillRect(screen, l.Position.X-width/2, l.End().Y, width, length, l.Visual.Color)
+	if core := math.Floor(width / 3); core >= 1 {
+		render.FillRect(screen, l.Position.X-core/2, l.End().Y, core, length, color.White)
+	}
+}
@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+func TestLaserAccumulatesDamage(t *testing.T) {
+	laser := NewLaser(30, 6)
+	laser.Aim(vector.New(100, 500))
+	laser.SetActive(true)
+
+	health := NewHealth(100)
+	for i := 0; i < 60; i++ {
+		_ = laser.Update(1.0 / 60)
+		health.Take(laser.Hit(), nil)
+	}
+
+	if dealt := health.Maximum - health.Current; dealt < 29 || dealt > 30 {
+		t.Errorf("Expected about 30 damage over one second, got %d", dealt)
+	}
+}
+
+func TestLaserThroughDefences(t *testing.T) {
+	tests := []struct {
+		name    string
+		defence Defence
+		want    int
+	}{
+		{name: "undefended", want: 60},
+		{name: "resistant", defence: Defence{Resistances: [damageTypeCount]float64{DamageEnergy: 0.3}}, want: 42},
+		{name: "weak", defence: Defence{Resistances: [damageTypeCount]float64{DamageEnergy: -0.5}}, want: 90},
+		{name: "armoured", defence: Defence{Armour: 2}, want: 58},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			laser := NewLaser(60, 6)
+			laser.SetActive(true)
+
+			health := NewHealth(1000)
+			defence := tt.defence
+			for i := 0; i < 60; i++ {
+				_ = laser.Update(1.0 / 60)
+				health.Take(laser.Hit(), &defence)
+			}
+
+			// Allow for floating point error in the last fraction
+			if dealt := health.Maximum - health.Current; dealt < tt.want-1 || dealt > tt.want {
+				t.Errorf("Expected about %d damage over one second, got %d", tt.want, dealt)
+			}
+		})
+	}
+}
+
+func TestInactiveLaserDealsNoDamage(t *testing.T) {
+	laser := NewLaser(600, 6)
+
+	_ = laser.Update(1.0 / 60)
+
+	if laser.TickDamage() != 0 {
+		t.Errorf("Inactive laser should deal no damage, got %g", laser.TickDamage())
+	}
+}
+
+func TestLaserReachesTopOfScreen(t *testing.T) {
+	laser := NewLaser(60, 6)
+	laser.Aim(vector.New(120, 480))
+
+	if laser.End() != vector.New(120, 0) {
+		t.Errorf("Expected beam to end at the top of the screen, got %v", laser.End())
+	}
+}
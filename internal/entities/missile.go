@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+// NewMissile creates a homing missile that turns toward its Target by at
+// most turnRate radians per second
+func NewMissile(x, y float64, velocity vector.Vector2, damage int, turnRate, screenWidth, screenHeight float64) *Bullet {
+	missile := NewBullet(x, y, velocity, damage, OwnerPlayer, screenWidth, screenHeight)
+	missile.Projectile = ProjectileMissile
+	missile.DamageType = DamageExplosive
+	missile.TurnRate = turnRate
+	missile.MaxLife = 4.0
+	missile.Radius = 4
+	missile.Visual = &Visual{
+		Color:  color.RGBA{R: 255, G: 170, B: 60, A: 255},
+		Width:  6,
+		Height: 16,
+	}
+	missile.faceVelocity()
+	return missile
+}
+
+// steer turns the missile toward its target
+func (b *Bullet) steer(dt float64) {
+	if b.Target == nil || !b.Target.IsActive() {
+		return
+	}
+
+	want := b.Target.GetPosition().Sub(b.Position)
+	b.Velocity = turnToward(b.Velocity, want, b.TurnRate*dt)
+	b.faceVelocity()
+}
+
+// faceVelocity points the missile's sprite along its direction of travel
+func (b *Bullet) faceVelocity() {
+	b.Visual.Angle = b.Velocity.Angle() + math.Pi/2
+}
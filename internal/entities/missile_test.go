@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+func TestMissileTurnsTowardTarget(t *testing.T) {
+	missile := NewMissile(400, 500, vector.New(0, -200), 20, 2, 800, 600)
+	missile.Target = NewEnemy(EnemyBasic, 700, 500, 800, 600, testBasicEnemy)
+
+	_ = missile.Update(0.1)
+
+	turned := math.Remainder(missile.Velocity.Angle()-vector.New(0, -200).Angle(), 2*math.Pi)
+	if math.Abs(turned-0.2) > 1e-9 {
+		t.Errorf("Expected missile to turn 0.2 rad toward the target, turned %g", turned)
+	}
+	if math.Abs(missile.Velocity.Length()-200) > 1e-9 {
+		t.Errorf("Turning should keep the missile's speed, got %g", missile.Velocity.Length())
+	}
+}
+
+func TestMissileFliesStraightWithoutTarget(t *testing.T) {
+	missile := NewMissile(400, 500, vector.New(0, -200), 20, 2, 800, 600)
+
+	_ = missile.Update(0.1)
+
+	if missile.Velocity != vector.New(0, -200) {
+		t.Errorf("Expected missile to keep its heading, got %v", missile.Velocity)
+	}
+}
+
+func TestPlayerProjectileFromConfig(t *testing.T) {
+	cfg := testPlayerConfig
+	cfg.Projectile = config.ProjectileMissile
+
+	weapon := NewPlayer(100, 100, 800, 600, cfg).Weapon
+	if weapon.ProjectileType != ProjectileMissile {
+		t.Fatalf("Expected missile weapon, got %v", weapon.ProjectileType)
+	}
+	if weapon.Damage != cfg.Missile.Damage || weapon.TurnRate != cfg.Missile.TurnRate {
+		t.Errorf("Expected missile settings, got %+v", weapon)
+	}
+}
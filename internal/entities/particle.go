@@ -4,6 +4,7 @@ import (
 	"image/color"
 	"math/rand"
 
+	"github.com/EchoSingh/space-shooter/internal/render"
 	"github.com/EchoSingh/space-shooter/pkg/vector"
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -55,34 +56,30 @@ func (p *Particle) Update(dt float64) error {
 	return nil
 }
 
-// Draw draws the particle
+// Draw draws the particle on its own. Drawing many at once is cheaper
+// through Batch.
 func (p *Particle) Draw(screen *ebiten.Image) {
-	alpha := 1.0
-	if p.Fade {
-		alpha = 1.0 - (p.LifeTime / p.MaxLife)
-	}
-
-	// Get the original color
-	r, g, b, _ := p.Visual.Color.RGBA()
-	particleColor := color.RGBA{
-		R: uint8(r >> 8),
-		G: uint8(g >> 8),
-		B: uint8(b >> 8),
-		A: uint8(alpha * 255),
-	}
+	size := p.Visual.Width
+	render.FillRectScale(screen, p.Position.X-size/2, p.Position.Y-size/2, size, size, p.colorScale())
+}
 
-	x, y := p.Position.X, p.Position.Y
+// Batch adds the particle to a batch of quads
+func (p *Particle) Batch(batch *render.Batch) {
 	size := p.Visual.Width
+	batch.Quad(p.Position.X-size/2, p.Position.Y-size/2, size, size, p.colorScale())
+}
 
-	img := ebiten.NewImage(int(size), int(size))
-	img.Fill(particleColor)
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(x-size/2, y-size/2)
-	screen.DrawImage(img, op)
+// colorScale tints the particle, fading it out over its life
+func (p *Particle) colorScale() ebiten.ColorScale {
+	cs := render.Scale(p.Visual.Color)
+	if p.Fade {
+		cs.ScaleAlpha(float32(1 - p.LifeTime/p.MaxLife))
+	}
+	return cs
 }
 
 // CreateExplosion creates explosion particles
-func CreateExplosion(x, y float64, count int) []*Particle {
+func CreateExplosion(rng *rand.Rand, x, y float64, count int) []*Particle {
 	particles := make([]*Particle, count)
 	colors := []color.Color{
 		color.RGBA{R: 255, G: 200, B: 0, A: 255},
@@ -92,15 +89,15 @@ func CreateExplosion(x, y float64, count int) []*Particle {
 	}
 
 	for i := 0; i < count; i++ {
-		speed := 50 + rand.Float64()*150
+		speed := 50 + rng.Float64()*150
 		velocity := vector.New(
-			speed*float64(rand.Float64()-0.5)*2,
-			speed*float64(rand.Float64()-0.5)*2,
+			speed*float64(rng.Float64()-0.5)*2,
+			speed*float64(rng.Float64()-0.5)*2,
 		)
 
-		particleColor := colors[rand.Intn(len(colors))]
-		size := 2 + rand.Float64()*4
-		life := 0.3 + rand.Float64()*0.7
+		particleColor := colors[rng.Intn(len(colors))]
+		size := 2 + rng.Float64()*4
+		life := 0.3 + rng.Float64()*0.7
 
 		particles[i] = NewParticle(x, y, velocity, particleColor, size, life)
 	}
@@ -109,10 +106,10 @@ func CreateExplosion(x, y float64, count int) []*Particle {
 }
 
 // CreateTrail creates trail particles
-func CreateTrail(x, y float64, velocity vector.Vector2) *Particle {
+func CreateTrail(rng *rand.Rand, x, y float64, velocity vector.Vector2) *Particle {
 	trailColor := color.RGBA{R: 100, G: 200, B: 255, A: 200}
-	size := 2.0 + rand.Float64()*2
-	life := 0.2 + rand.Float64()*0.3
+	size := 2.0 + rng.Float64()*2
+	life := 0.2 + rng.Float64()*0.3
 
 	// Trail moves opposite to entity
 	trailVelocity := velocity.Mul(-0.3)
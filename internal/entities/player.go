@@ -2,27 +2,42 @@ package entities
 
 import (
 	"image/color"
+	"math"
 
+	"github.com/EchoSingh/space-shooter/internal/assets"
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/input"
+	"github.com/EchoSingh/space-shooter/internal/render"
 	"github.com/EchoSingh/space-shooter/pkg/vector"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
 const (
-	PlayerSpeed        = 300.0
-	PlayerFireRate     = 0.15
-	PlayerMaxHealth    = 100
-	PlayerRadius       = 20.0
-	PlayerBulletSpeed  = 500.0
-	PlayerBulletDamage = 10
+	PlayerRadius = 20.0
 )
 
 // Player represents the player's spaceship
 type Player struct {
 	BaseEntity
-	Health *Health
-	Weapon *Weapon
-	Visual *Visual
-	Score  int
+	Health   *Health
+	Weapon   *Weapon
+	Visual   *Visual
+	Hitbox   *Hitbox
+	Animator *Animator
+	Speed    float64
+	Score    int
+	Lives    int
+
+	// Seconds left without taking damage, and the next extra life to award
+	invulnerable  float64
+	nextExtraLife int
+
+	// Timed power-up effects
+	Effects     EffectTimers
+	powerUps    config.PowerUpsConfig
+	weaponBoost PowerUpKind
+
+	config config.PlayerConfig
 
 	// Input state
 	moveUp    bool
@@ -36,8 +51,8 @@ type Player struct {
 	screenHeight float64
 }
 
-// NewPlayer creates a new player
-func NewPlayer(x, y, screenWidth, screenHeight float64) *Player {
+// NewPlayer creates a new player tuned by cfg
+func NewPlayer(x, y, screenWidth, screenHeight float64, cfg config.PlayerConfig) *Player {
 	return &Player{
 		BaseEntity: BaseEntity{
 			Position: vector.New(x, y),
@@ -46,30 +61,59 @@ func NewPlayer(x, y, screenWidth, screenHeight float64) *Player {
 			Type:     TypePlayer,
 			Radius:   PlayerRadius,
 		},
-		Health: NewHealth(PlayerMaxHealth),
-		Weapon: &Weapon{
-			Damage:         PlayerBulletDamage,
-			FireRate:       PlayerFireRate,
-			BulletSpeed:    PlayerBulletSpeed,
-			ProjectileType: ProjectileNormal,
-		},
+		Health: NewHealth(cfg.Health),
+		Weapon: newPlayerWeapon(cfg),
 		Visual: &Visual{
 			Color:  color.RGBA{R: 100, G: 200, B: 255, A: 255},
 			Width:  45,
 			Height: 55,
 		},
+		Hitbox: &Hitbox{
+			Width:  45,
+			Height: 55,
+		},
+		Speed:        cfg.Speed,
+		Lives:        cfg.Lives,
+		config:       cfg,
 		screenWidth:  screenWidth,
 		screenHeight: screenHeight,
 	}
 }
 
+// newPlayerWeapon arms the ship with the configured projectile type
+func newPlayerWeapon(cfg config.PlayerConfig) *Weapon {
+	w := &Weapon{
+		Damage:         cfg.BulletDamage,
+		FireRate:       cfg.FireRate,
+		BulletSpeed:    cfg.BulletSpeed,
+		ProjectileType: ParseProjectileType(cfg.Projectile),
+	}
+
+	switch w.ProjectileType {
+	case ProjectileSpread:
+		w.BurstCount = cfg.Spread.Count
+		w.Spread = cfg.Spread.Angle * math.Pi / 180
+	case ProjectileMissile:
+		w.Damage = cfg.Missile.Damage
+		w.FireRate = cfg.Missile.FireRate
+		w.BulletSpeed = cfg.Missile.Speed
+		w.TurnRate = cfg.Missile.TurnRate
+	case ProjectileLaser:
+		w.DamagePerSecond = cfg.Laser.DamagePerSecond
+		w.BeamWidth = cfg.Laser.Width
+	}
+	return w
+}
+
 // Update updates the player
 func (p *Player) Update(dt float64) error {
-	// Update weapon
-	p.Weapon.Update(dt)
+	p.invulnerable = math.Max(0, p.invulnerable-dt)
 
-	// Handle input
-	p.handleInput()
+	// Update weapon and expire power-ups
+	p.Weapon.Update(dt)
+	if p.Effects.Update(dt) {
+		p.rearm()
+	}
 
 	// Calculate movement
 	direction := vector.Zero()
@@ -92,7 +136,7 @@ func (p *Player) Update(dt float64) error {
 	}
 
 	// Apply velocity
-	p.Velocity = direction.Mul(PlayerSpeed)
+	p.Velocity = direction.Mul(p.Speed)
 	p.Position = p.Position.Add(p.Velocity.Mul(dt))
 
 	// Clamp to screen bounds
@@ -101,29 +145,53 @@ func (p *Player) Update(dt float64) error {
 		vector.New(p.screenWidth-PlayerRadius, p.screenHeight-PlayerRadius),
 	)
 
+	// Bank with sideways movement, colliding as the sprite shows
+	if p.Animator != nil {
+		p.Animator.Bank(p.Velocity.X)
+		p.Animator.Update(dt)
+		p.Animator.Fit(p.Hitbox, p.Visual)
+	}
+
 	return nil
 }
 
-func (p *Player) handleInput() {
-	p.moveUp = ebiten.IsKeyPressed(ebiten.KeyW) || ebiten.IsKeyPressed(ebiten.KeyArrowUp)
-	p.moveDown = ebiten.IsKeyPressed(ebiten.KeyS) || ebiten.IsKeyPressed(ebiten.KeyArrowDown)
-	p.moveLeft = ebiten.IsKeyPressed(ebiten.KeyA) || ebiten.IsKeyPressed(ebiten.KeyArrowLeft)
-	p.moveRight = ebiten.IsKeyPressed(ebiten.KeyD) || ebiten.IsKeyPressed(ebiten.KeyArrowRight)
-	p.firing = ebiten.IsKeyPressed(ebiten.KeySpace)
+// HandleInput sets the player's controls for the next Update
+func (p *Player) HandleInput(actions input.Action) {
+	p.moveUp = actions.Has(input.ActionUp)
+	p.moveDown = actions.Has(input.ActionDown)
+	p.moveLeft = actions.Has(input.ActionLeft)
+	p.moveRight = actions.Has(input.ActionRight)
+	p.firing = actions.Has(input.ActionFire)
+}
+
+// Submit queues the player for drawing
+func (p *Player) Submit(q *render.Queue) {
+	q.Submit(render.LayerGameplay, ZPlayer, p)
 }
 
 // Draw draws the player
 func (p *Player) Draw(screen *ebiten.Image) {
-	// Draw player ship as a simple circle for now
-	x, y := float32(p.Position.X), float32(p.Position.Y)
-	w := float32(p.Visual.Width / 2)
+	// The shield and the fallback shape cover the ship's visual size
+	x, y := p.Position.X, p.Position.Y
+	w, h := p.Visual.Width/2, p.Visual.Height/2
 
-	// Draw simple representation
-	img := ebiten.NewImage(int(w*2), int(w*2))
-	img.Fill(p.Visual.Color)
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(float64(x-w), float64(y-w))
-	screen.DrawImage(img, op)
+	// Draw shield glow
+	if p.Effects.Active(EffectShield) {
+		render.FillRect(screen, x-w-6, y-h-6, w*2+12, h*2+12, color.RGBA{R: 80, G: 160, B: 255, A: 90})
+	}
+
+	// Blink every tenth of a second while invulnerable
+	if p.Invulnerable() && int(p.invulnerable*10)%2 == 0 {
+		p.drawHealthBar(screen)
+		return
+	}
+
+	// Draw the sprite, or a simple representation without one
+	if p.Animator != nil {
+		p.Animator.Draw(screen, p.Position, p.Visual)
+	} else {
+		render.FillRectRotated(screen, x, y, w*2, h*2, p.Visual.Angle, p.Visual.Color)
+	}
 
 	// Draw health bar
 	p.drawHealthBar(screen)
@@ -136,11 +204,7 @@ func (p *Player) drawHealthBar(screen *ebiten.Image) {
 	y := p.Position.Y + p.Visual.Height/2 + 10
 
 	// Background
-	bgImg := ebiten.NewImage(int(barWidth), int(barHeight))
-	bgImg.Fill(color.RGBA{R: 50, G: 50, B: 50, A: 255})
-	bgOp := &ebiten.DrawImageOptions{}
-	bgOp.GeoM.Translate(x, y)
-	screen.DrawImage(bgImg, bgOp)
+	render.FillRect(screen, x, y, barWidth, barHeight, color.RGBA{R: 50, G: 50, B: 50, A: 255})
 
 	// Health
 	healthWidth := barWidth * p.Health.GetPercentage()
@@ -153,19 +217,26 @@ func (p *Player) drawHealthBar(screen *ebiten.Image) {
 		} else if p.Health.GetPercentage() < 0.6 {
 			healthColor = color.RGBA{R: 255, G: 255, B: 100, A: 255}
 		}
-		healthImg := ebiten.NewImage(int(healthWidth), int(barHeight))
-		healthImg.Fill(healthColor)
-		healthOp := &ebiten.DrawImageOptions{}
-		healthOp.GeoM.Translate(x, y)
-		screen.DrawImage(healthImg, healthOp)
+		render.FillRect(screen, x, y, healthWidth, barHeight, healthColor)
 	}
 }
 
+// GetHitbox returns the player's collision box
+func (p *Player) GetHitbox() *Hitbox {
+	p.Hitbox.Angle = p.Visual.Angle
+	return p.Hitbox
+}
+
 // IsFiring returns whether the player is firing
 func (p *Player) IsFiring() bool {
 	return p.firing && p.Weapon.CanFire()
 }
 
+// TriggerHeld returns whether fire is held, regardless of the weapon's fire rate
+func (p *Player) TriggerHeld() bool {
+	return p.firing
+}
+
 // FireWeapon fires the weapon
 func (p *Player) FireWeapon() {
 	p.Weapon.Fire()
@@ -175,15 +246,106 @@ func (p *Player) FireWeapon() {
 func (p *Player) OnCollision(other Entity) {
 	switch other.GetType() {
 	case TypeEnemy:
-		p.Health.Damage(10)
+		p.TakeDamage(10)
 	case TypePowerUp:
-		// Handle power-up
+		// Collect needs the power-up tuning, so Game calls it directly
+	}
+}
+
+// TakeDamage damages the player unless a shield is up or they are invulnerable
+func (p *Player) TakeDamage(amount int) {
+	if p.Effects.Active(EffectShield) || p.Invulnerable() {
+		return
+	}
+	p.Health.Damage(amount)
+	if p.Animator != nil {
+		p.Animator.Trigger(assets.AnimHit)
+	}
+}
+
+// Invulnerable reports whether the player is ignoring collisions after
+// respawning or being rammed
+func (p *Player) Invulnerable() bool {
+	return p.invulnerable > 0
+}
+
+// Protect makes the player invulnerable for at least the given seconds
+func (p *Player) Protect(seconds float64) {
+	p.invulnerable = math.Max(p.invulnerable, seconds)
+}
+
+// LoseLife takes a life and reports whether any are left
+func (p *Player) LoseLife() bool {
+	if p.Lives > 0 {
+		p.Lives--
+	}
+	return p.Lives > 0
+}
+
+// Respawn brings the ship back at x, y with full health, its power-ups
+// lost and a spell of invulnerability
+func (p *Player) Respawn(x, y float64) {
+	p.Position = vector.New(x, y)
+	p.Velocity = vector.Zero()
+	p.Active = true
+	p.Health = NewHealth(p.config.Health)
+	p.Effects = EffectTimers{}
+	p.rearm()
+	p.Protect(p.config.Invulnerability)
+}
+
+// Collect applies a power-up tuned by cfg
+func (p *Player) Collect(powerUp *PowerUp, cfg config.PowerUpsConfig) {
+	p.powerUps = cfg
+
+	switch kind := powerUp.Kind; {
+	case kind == PowerUpHeal:
+		p.Health.Heal(cfg.HealAmount)
+	case kind == PowerUpShield:
+		p.Effects.Start(EffectShield, cfg.Duration)
+	case kind == PowerUpRapidFire:
+		p.Effects.Start(EffectRapidFire, cfg.Duration)
+	case kind == PowerUpScore:
+		p.Effects.Start(EffectScore, cfg.Duration)
+	case kind.IsWeapon():
+		p.weaponBoost = kind
+		p.Effects.Start(EffectWeapon, cfg.Duration)
 	}
+	p.rearm()
 }
 
-// AddScore adds to the player's score
-func (p *Player) AddScore(points int) {
+// rearm rebuilds the weapon from the config and the active effects,
+// keeping its reload timer
+func (p *Player) rearm() {
+	cfg := p.config
+	if p.Effects.Active(EffectWeapon) {
+		// Weapon power-ups share their names with projectile types
+		cfg.Projectile = p.weaponBoost.String()
+	}
+
+	w := newPlayerWeapon(cfg)
+	if p.Effects.Active(EffectRapidFire) {
+		w.FireRate /= p.powerUps.RapidFire
+	}
+	w.CurrentTime = p.Weapon.CurrentTime
+	w.LastFireTime = p.Weapon.LastFireTime
+	p.Weapon = w
+}
+
+// AddScore adds to the player's score, boosted by any score multiplier,
+// and returns the points added
+func (p *Player) AddScore(points int) int {
+	if p.Effects.Active(EffectScore) {
+		points *= p.powerUps.ScoreMultiplier
+	}
 	p.Score += points
+
+	// Award any extra lives the new score has reached
+	for p.nextExtraLife < len(p.config.ExtraLives) && p.Score >= p.config.ExtraLives[p.nextExtraLife] {
+		p.nextExtraLife++
+		p.Lives = min(p.Lives+1, p.config.MaxLives)
+	}
+	return points
 }
 
 // GetScore returns the current score
@@ -3,14 +3,15 @@ package entities
 import (
 	"testing"
 
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/input"
 	"github.com/EchoSingh/space-shooter/pkg/vector"
 )
 
+var testPlayerConfig = config.Default().Player
+
 func TestNewPlayer(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping test in short mode (CI environment)")
-	}
-	player := NewPlayer(100, 100, 800, 600)
+	player := NewPlayer(100, 100, 800, 600, testPlayerConfig)
 
 	if player == nil {
 		t.Fatal("NewPlayer returned nil")
@@ -20,8 +21,8 @@ func TestNewPlayer(t *testing.T) {
 		t.Error("Player should be active")
 	}
 
-	if player.Health.Current != PlayerMaxHealth {
-		t.Errorf("Expected health %d, got %d", PlayerMaxHealth, player.Health.Current)
+	if player.Health.Current != testPlayerConfig.Health {
+		t.Errorf("Expected health %d, got %d", testPlayerConfig.Health, player.Health.Current)
 	}
 
 	if player.GetType() != TypePlayer {
@@ -30,10 +31,7 @@ func TestNewPlayer(t *testing.T) {
 }
 
 func TestPlayerMovement(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping test in short mode (CI environment)")
-	}
-	player := NewPlayer(100, 100, 800, 600)
+	player := NewPlayer(100, 100, 800, 600, testPlayerConfig)
 	initialPos := player.GetPosition()
 
 	// Directly set position to test movement works
@@ -44,11 +42,41 @@ func TestPlayerMovement(t *testing.T) {
 	}
 }
 
-func TestPlayerBoundaries(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping test in short mode (CI environment)")
+func TestPlayerInput(t *testing.T) {
+	player := NewPlayer(100, 100, 800, 600, testPlayerConfig)
+
+	player.HandleInput(input.ActionUp | input.ActionRight)
+	_ = player.Update(0.1)
+
+	pos := player.GetPosition()
+	if pos.Y >= 100 || pos.X <= 100 {
+		t.Errorf("Expected player to move up and right, got %v", pos)
+	}
+
+	player.HandleInput(input.None)
+	_ = player.Update(0.1)
+
+	if player.GetPosition() != pos {
+		t.Error("Player should stop without input")
 	}
-	player := NewPlayer(10, 10, 800, 600)
+}
+
+func TestPlayerFiringInput(t *testing.T) {
+	player := NewPlayer(100, 100, 800, 600, testPlayerConfig)
+	player.Weapon.Update(1.0)
+
+	if player.IsFiring() {
+		t.Error("Player should not fire without input")
+	}
+
+	player.HandleInput(input.ActionFire)
+	if !player.IsFiring() {
+		t.Error("Player should fire while fire is held")
+	}
+}
+
+func TestPlayerBoundaries(t *testing.T) {
+	player := NewPlayer(10, 10, 800, 600, testPlayerConfig)
 
 	// Try to move off screen
 	player.Position = vector.New(-100, -100)
@@ -61,10 +89,7 @@ func TestPlayerBoundaries(t *testing.T) {
 }
 
 func TestPlayerWeapon(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping test in short mode (CI environment)")
-	}
-	player := NewPlayer(100, 100, 800, 600)
+	player := NewPlayer(100, 100, 800, 600, testPlayerConfig)
 
 	// Update weapon time so it can fire
 	player.Weapon.Update(1.0)
@@ -82,10 +107,7 @@ func TestPlayerWeapon(t *testing.T) {
 }
 
 func TestPlayerHealth(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping test in short mode (CI environment)")
-	}
-	player := NewPlayer(100, 100, 800, 600)
+	player := NewPlayer(100, 100, 800, 600, testPlayerConfig)
 
 	initialHealth := player.Health.Current
 	player.Health.Damage(10)
@@ -105,10 +127,7 @@ func TestPlayerHealth(t *testing.T) {
 }
 
 func TestPlayerScore(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping test in short mode (CI environment)")
-	}
-	player := NewPlayer(100, 100, 800, 600)
+	player := NewPlayer(100, 100, 800, 600, testPlayerConfig)
 
 	if player.GetScore() != 0 {
 		t.Error("Initial score should be 0")
@@ -126,12 +145,68 @@ func TestPlayerScore(t *testing.T) {
 }
 
 func BenchmarkPlayerUpdate(b *testing.B) {
-	if testing.Short() {
-		b.Skip("Skipping benchmark in short mode (CI environment)")
-	}
-	player := NewPlayer(100, 100, 800, 600)
+	player := NewPlayer(100, 100, 800, 600, testPlayerConfig)
 
 	for i := 0; i < b.N; i++ {
 		_ = player.Update(0.016)
 	}
 }
+
+func TestPlayerLivesAndRespawn(t *testing.T) {
+	player := NewPlayer(100, 100, 800, 600, testPlayerConfig)
+	player.Collect(&PowerUp{Kind: PowerUpSpread}, config.Default().PowerUps)
+	player.Health.Damage(testPlayerConfig.Health)
+
+	if !player.LoseLife() || player.Lives != testPlayerConfig.Lives-1 {
+		t.Fatalf("Expected a life to be lost with lives to spare, have %d", player.Lives)
+	}
+	player.Respawn(400, 500)
+
+	if player.Health.Current != testPlayerConfig.Health || player.GetPosition() != vector.New(400, 500) {
+		t.Errorf("Expected a fresh ship at the respawn point, got health %d at %v", player.Health.Current, player.GetPosition())
+	}
+	if player.Weapon.ProjectileType != ProjectileNormal {
+		t.Errorf("Expected power-ups to be lost on respawn, weapon is %s", player.Weapon.ProjectileType)
+	}
+
+	player.TakeDamage(50)
+	if !player.Invulnerable() || player.Health.Current != testPlayerConfig.Health {
+		t.Error("Expected the respawned ship to be invulnerable")
+	}
+
+	for i := 0; i < int(testPlayerConfig.Invulnerability*60)+1; i++ {
+		_ = player.Update(1.0 / 60)
+	}
+	player.TakeDamage(50)
+	if player.Invulnerable() || player.Health.Current != testPlayerConfig.Health-50 {
+		t.Error("Expected invulnerability to wear off")
+	}
+
+	for player.Lives > 1 {
+		player.LoseLife()
+	}
+	if player.LoseLife() {
+		t.Error("Expected the last life to end the game")
+	}
+}
+
+func TestPlayerEarnsExtraLives(t *testing.T) {
+	cfg := testPlayerConfig
+	cfg.Lives = 3
+	cfg.MaxLives = 4
+	cfg.ExtraLives = []int{100, 200, 300}
+	player := NewPlayer(100, 100, 800, 600, cfg)
+
+	player.AddScore(99)
+	if player.Lives != 3 {
+		t.Errorf("Expected no extra life below 100 points, have %d", player.Lives)
+	}
+	player.AddScore(1)
+	if player.Lives != 4 {
+		t.Errorf("Expected an extra life at 100 points, have %d", player.Lives)
+	}
+	player.AddScore(500)
+	if player.Lives != cfg.MaxLives {
+		t.Errorf("Expected lives to stop at %d, have %d", cfg.MaxLives, player.Lives)
+	}
+}
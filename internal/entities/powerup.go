@@ -0,0 +1,175 @@
+package entities
+
+import (
+	"image/color"
+	"math/rand"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/render"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// PowerUpKind represents what a power-up does when collected
+type PowerUpKind int
+
+const (
+	PowerUpHeal PowerUpKind = iota
+	PowerUpShield
+	PowerUpRapidFire
+	PowerUpSpread
+	PowerUpMissile
+	PowerUpLaser
+	PowerUpScore
+)
+
+// PowerUpKinds lists every power-up kind in drop table order
+var PowerUpKinds = []PowerUpKind{
+	PowerUpHeal, PowerUpShield, PowerUpRapidFire, PowerUpSpread, PowerUpMissile, PowerUpLaser, PowerUpScore,
+}
+
+// String returns the power-up's name as used in the config file
+func (k PowerUpKind) String() string {
+	switch k {
+	case PowerUpHeal:
+		return config.PowerUpHeal
+	case PowerUpShield:
+		return config.PowerUpShield
+	case PowerUpRapidFire:
+		return config.PowerUpRapidFire
+	case PowerUpSpread:
+		return config.PowerUpSpread
+	case PowerUpMissile:
+		return config.PowerUpMissile
+	case PowerUpLaser:
+		return config.PowerUpLaser
+	case PowerUpScore:
+		return config.PowerUpScore
+	}
+	return "unknown"
+}
+
+// IsWeapon reports whether the power-up swaps the player's projectile type
+func (k PowerUpKind) IsWeapon() bool {
+	return k == PowerUpSpread || k == PowerUpMissile || k == PowerUpLaser
+}
+
+// PowerUp is a pickup dropped by enemies that drifts down the screen
+type PowerUp struct {
+	BaseEntity
+	Visual *Visual
+	Kind   PowerUpKind
+
+	screenHeight float64
+}
+
+// NewPowerUp creates a power-up falling at fallSpeed
+func NewPowerUp(kind PowerUpKind, x, y, fallSpeed, screenHeight float64) *PowerUp {
+	return &PowerUp{
+		BaseEntity: BaseEntity{
+			Position: vector.New(x, y),
+			Velocity: vector.New(0, fallSpeed),
+			Active:   true,
+			Type:     TypePowerUp,
+			Radius:   12,
+		},
+		Visual: &Visual{
+			Color:  powerUpColor(kind),
+			Width:  20,
+			Height: 20,
+		},
+		Kind:         kind,
+		screenHeight: screenHeight,
+	}
+}
+
+func powerUpColor(kind PowerUpKind) color.Color {
+	switch kind {
+	case PowerUpHeal:
+		return color.RGBA{R: 80, G: 220, B: 80, A: 255}
+	case PowerUpShield:
+		return color.RGBA{R: 80, G: 160, B: 255, A: 255}
+	case PowerUpRapidFire:
+		return color.RGBA{R: 255, G: 220, B: 60, A: 255}
+	case PowerUpScore:
+		return color.RGBA{R: 255, G: 120, B: 220, A: 255}
+	}
+	return color.RGBA{R: 255, G: 150, B: 50, A: 255}
+}
+
+// Update moves the power-up down the screen
+func (p *PowerUp) Update(dt float64) error {
+	p.Position = p.Position.Add(p.Velocity.Mul(dt))
+
+	if p.Position.Y > p.screenHeight+20 {
+		p.Active = false
+	}
+	return nil
+}
+
+// Submit queues the power-up for drawing
+func (p *PowerUp) Submit(q *render.Queue) {
+	q.Submit(render.LayerGameplay, ZPowerUps, p)
+}
+
+// Draw draws the power-up as a coloured tile with its initial
+func (p *PowerUp) Draw(screen *ebiten.Image) {
+	x, y := p.Position.X, p.Position.Y
+	w, h := p.Visual.Width/2, p.Visual.Height/2
+
+	render.FillRect(screen, x-w, y-h, p.Visual.Width, p.Visual.Height, p.Visual.Color)
+
+	ebitenutil.DebugPrintAt(screen, p.Kind.label(), int(x-w)+4, int(y-h)+2)
+}
+
+func (k PowerUpKind) label() string {
+	switch k {
+	case PowerUpHeal:
+		return "H"
+	case PowerUpShield:
+		return "S"
+	case PowerUpRapidFire:
+		return "R"
+	case PowerUpSpread:
+		return "W"
+	case PowerUpMissile:
+		return "M"
+	case PowerUpLaser:
+		return "L"
+	case PowerUpScore:
+		return "x"
+	}
+	return "?"
+}
+
+// RollPowerUp decides whether an enemy with the given stats drops a
+// power-up, and which one, using its weighted drop table
+func RollPowerUp(rng *rand.Rand, stats config.EnemyTypeConfig) (PowerUpKind, bool) {
+	if stats.DropChance <= 0 || rng.Float64() >= stats.DropChance {
+		return 0, false
+	}
+
+	total := 0.0
+	for _, kind := range PowerUpKinds {
+		total += stats.Drops[kind.String()]
+	}
+	if total <= 0 {
+		return 0, false
+	}
+
+	var last PowerUpKind
+	roll := rng.Float64() * total
+	for _, kind := range PowerUpKinds {
+		weight := stats.Drops[kind.String()]
+		if weight <= 0 {
+			continue
+		}
+		last = kind
+		roll -= weight
+		if roll < 0 {
+			return kind, true
+		}
+	}
+	return last, true
+}
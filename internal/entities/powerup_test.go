@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+)
+
+var testPowerUps = config.Default().PowerUps
+
+func TestRollPowerUpUsesDropTable(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	stats := config.EnemyTypeConfig{
+		DropChance: 1,
+		Drops:      map[string]float64{config.PowerUpShield: 1, config.PowerUpHeal: 0},
+	}
+
+	for i := 0; i < 100; i++ {
+		kind, ok := RollPowerUp(rng, stats)
+		if !ok || kind != PowerUpShield {
+			t.Fatalf("Expected only shields to drop, got %v, %v", kind, ok)
+		}
+	}
+
+	stats.DropChance = 0
+	if _, ok := RollPowerUp(rng, stats); ok {
+		t.Error("Expected no drop with zero drop chance")
+	}
+}
+
+func TestPowerUpFallsOffScreen(t *testing.T) {
+	powerUp := NewPowerUp(PowerUpHeal, 100, 590, 80, 600)
+
+	_ = powerUp.Update(0.5)
+
+	if powerUp.IsActive() {
+		t.Error("Power-up should deactivate below the screen")
+	}
+}
+
+func TestCollectHealAndShield(t *testing.T) {
+	player := NewPlayer(100, 100, 800, 600, testPlayerConfig)
+	player.Health.Damage(50)
+
+	player.Collect(NewPowerUp(PowerUpHeal, 0, 0, 80, 600), testPowerUps)
+	if want := testPlayerConfig.Health - 50 + testPowerUps.HealAmount; player.Health.Current != want {
+		t.Errorf("Expected health %d after heal, got %d", want, player.Health.Current)
+	}
+
+	player.Collect(NewPowerUp(PowerUpShield, 0, 0, 80, 600), testPowerUps)
+	before := player.Health.Current
+	player.TakeDamage(20)
+	if player.Health.Current != before {
+		t.Error("Shield should block damage")
+	}
+
+	_ = player.Update(testPowerUps.Duration)
+	player.TakeDamage(20)
+	if player.Health.Current != before-20 {
+		t.Error("Expired shield should no longer block damage")
+	}
+}
+
+func TestTimedWeaponEffectsExpire(t *testing.T) {
+	player := NewPlayer(100, 100, 800, 600, testPlayerConfig)
+
+	player.Collect(NewPowerUp(PowerUpRapidFire, 0, 0, 80, 600), testPowerUps)
+	player.Collect(NewPowerUp(PowerUpMissile, 0, 0, 80, 600), testPowerUps)
+
+	if player.Weapon.ProjectileType != ProjectileMissile {
+		t.Errorf("Expected missile weapon, got %v", player.Weapon.ProjectileType)
+	}
+	if want := testPlayerConfig.Missile.FireRate / testPowerUps.RapidFire; player.Weapon.FireRate != want {
+		t.Errorf("Expected rapid fire rate %g, got %g", want, player.Weapon.FireRate)
+	}
+
+	_ = player.Update(testPowerUps.Duration)
+
+	if player.Weapon.ProjectileType != ProjectileNormal || player.Weapon.FireRate != testPlayerConfig.FireRate {
+		t.Errorf("Expected the configured weapon back, got %+v", player.Weapon)
+	}
+}
+
+func TestScoreMultiplier(t *testing.T) {
+	player := NewPlayer(100, 100, 800, 600, testPlayerConfig)
+
+	player.Collect(NewPowerUp(PowerUpScore, 0, 0, 80, 600), testPowerUps)
+	player.AddScore(10)
+
+	if want := 10 * testPowerUps.ScoreMultiplier; player.GetScore() != want {
+		t.Errorf("Expected score %d, got %d", want, player.GetScore())
+	}
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"github.com/EchoSingh/space-shooter/internal/assets"
+	"github.com/EchoSingh/space-shooter/internal/render"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// wreckDrift is how much of a ship's speed its wreck keeps
+const wreckDrift = 0.3
+
+// Wreck plays a destroyed ship's death animation where it was destroyed.
+// It is only for show and never collides.
+type Wreck struct {
+	BaseEntity
+	Visual   Visual
+	Animator *Animator
+}
+
+// NewWreck creates the wreck of a ship drawn by animator at the size of
+// visual. It returns nil when the ship has no death animation to play.
+func NewWreck(animator *Animator, position, velocity vector.Vector2, visual *Visual) *Wreck {
+	if animator == nil || animator.Sprite.Animation(assets.AnimDeath) == nil {
+		return nil
+	}
+
+	w := &Wreck{
+		BaseEntity: BaseEntity{
+			Position: position,
+			Velocity: velocity.Mul(wreckDrift),
+			Active:   true,
+			Type:     TypeParticle,
+		},
+		Visual:   *visual,
+		Animator: animator.Clone(),
+	}
+	w.Animator.once = track{} // no hit flash over the death
+	w.Animator.Play(assets.AnimDeath)
+	return w
+}
+
+// Update drifts the wreck and plays its death animation, removing it
+// once the animation ends
+func (w *Wreck) Update(dt float64) error {
+	w.Position = w.Position.Add(w.Velocity.Mul(dt))
+	w.Animator.Update(dt)
+	if w.Animator.Done() {
+		w.Active = false
+	}
+	return nil
+}
+
+// Submit queues the wreck for drawing
+func (w *Wreck) Submit(q *render.Queue) {
+	q.Submit(render.LayerGameplay, ZWrecks, w)
+}
+
+// Draw draws the wreck
+func (w *Wreck) Draw(screen *ebiten.Image) {
+	w.Animator.Draw(screen, w.Position, &w.Visual)
+}
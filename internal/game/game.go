@@ -1,113 +1,275 @@
 package game
 
 import (
+	"errors"
 	"fmt"
-	"image/color"
+	"math"
 	"math/rand"
+	"strings"
 	"time"
 
+	"github.com/EchoSingh/space-shooter/internal/assets"
+	"github.com/EchoSingh/space-shooter/internal/background"
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/director"
 	"github.com/EchoSingh/space-shooter/internal/engine"
 	"github.com/EchoSingh/space-shooter/internal/entities"
+	"github.com/EchoSingh/space-shooter/internal/highscore"
+	"github.com/EchoSingh/space-shooter/internal/input"
+	"github.com/EchoSingh/space-shooter/internal/level"
 	"github.com/EchoSingh/space-shooter/internal/physics"
+	"github.com/EchoSingh/space-shooter/internal/postfx"
+	"github.com/EchoSingh/space-shooter/internal/render"
+	"github.com/EchoSingh/space-shooter/internal/scoring"
 	"github.com/EchoSingh/space-shooter/internal/ui"
 	"github.com/EchoSingh/space-shooter/pkg/vector"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 // Game represents the main game
 type Game struct {
 	screenWidth  int
 	screenHeight int
+	config       *config.Config
 
 	// State management
 	stateManager *engine.StateManager
 
+	// Input
+	input       input.Source
+	actions     input.Action
+	prevActions input.Action
+
 	// Game entities
 	player    *entities.Player
 	enemies   []*entities.Enemy
 	bullets   []*entities.Bullet
 	particles []*entities.Particle
+	wrecks    []*entities.Wreck
+	powerUps  []*entities.PowerUp
+	laser     *entities.Laser
+	laserHits []entities.Entity
+
+	// The boss in play, or the one whose warning is showing, and any
+	// bosses waiting for it to go
+	boss        *entities.Boss
+	bossWarning float64
+	bossQueue   []level.Spawn
+	// What enemies can see when steering, shared by all of them
+	world entities.World
+
+	// Scripted waves; without a level enemies spawn at random
+	level        *level.Level
+	waves        *level.Runner
+	wavesCleared int
 
 	// Systems
+	spawner         *director.Spawner
+	difficulty      *director.Difficulty
+	scorer          *scoring.Scorer
 	collisionSystem *physics.CollisionSystem
 	ui              *ui.UI
+	sprites         *assets.Library
+
+	// Drawing: each frame the scene and screens are queued by layer
+	queue      *render.Queue
+	scene      []render.Source
+	background *background.Background
+	overlay    render.Drawable
+	batch      *render.Batch
+	postfx     *postfx.Chain
 
 	// Gameplay
+	tick          uint64
 	spawnTimer    float64
 	spawnInterval float64
-	difficulty    float64
 	gameTime      float64
 
-	// Background
-	stars []Star
+	// High scores; without a table runs aren't recorded
+	scores     *highscore.Table
+	scoresPath string
+	scoresErr  error
+	scoreRank  int
+	nameEntry  *highscore.NameEntry
+	lastName   string
+
+	// Randomness
+	rng       *rand.Rand
+	seed      int64
+	fixedSeed bool
 }
 
-// Star represents a background star
-type Star struct {
-	X, Y  float64
-	Speed float64
-	Size  float64
-	Color color.Color
-}
+// bossWarningTime is how long the warning shows before a boss enters
+const bossWarningTime = 3.0
 
-// NewGame creates a new game instance
-func NewGame(screenWidth, screenHeight int) (*Game, error) {
+// contactDamage is dealt to the player when they ram an enemy or boss
+const contactDamage = 20
+
+// NewGame creates a new game instance tuned by cfg and driven by src.
+// Every run uses the given seed; a seed of 0 picks a new one per run.
+func NewGame(cfg *config.Config, src input.Source, seed int64) (*Game, error) {
+	if src == nil {
+		return nil, errors.New("game: input source is required")
+	}
+	if cfg == nil {
+		cfg = config.Default()
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	sprites, err := assets.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading sprites: %w", err)
+	}
 
+	screenWidth, screenHeight := cfg.Game.Width, cfg.Game.Height
 	g := &Game{
 		screenWidth:     screenWidth,
 		screenHeight:    screenHeight,
+		config:          cfg,
 		stateManager:    engine.NewStateManager(),
+		input:           src,
 		enemies:         make([]*entities.Enemy, 0, 50),
 		bullets:         make([]*entities.Bullet, 0, 100),
-		particles:       make([]*entities.Particle, 0, 200),
-		collisionSystem: physics.NewCollisionSystem(),
+		particles:       make([]*entities.Particle, 0, cfg.Particles.MaxParticles),
+		powerUps:        make([]*entities.PowerUp, 0, 8),
+		spawner:         director.NewSpawner(cfg),
+		collisionSystem: physics.NewCollisionSystem(physics.WithLayers(collisionLayers())),
 		ui:              ui.NewUI(screenWidth, screenHeight),
-		spawnInterval:   2.0,
-		difficulty:      1.0,
+		sprites:         sprites,
+		spawnInterval:   cfg.Enemies.SpawnInterval,
+		difficulty:      director.NewDifficulty(cfg.Difficulty),
+		scorer:          scoring.NewScorer(cfg.Scoring),
+		postfx:          postfx.New(cfg.PostFX),
+		scoreRank:       -1,
+		seed:            seed,
+		fixedSeed:       seed != 0,
 	}
 
-	// Initialize background stars
-	g.initStars()
+	g.queue = render.NewQueue()
+	g.scene = g.newScene()
+	g.overlay = render.DrawFunc(g.drawOverlay)
+
+	// Seed the simulation and lay out the background
+	g.reseed()
+	g.resetBackground()
 
 	return g, nil
 }
 
-func (g *Game) initStars() {
-	g.stars = make([]Star, 100)
-	for i := range g.stars {
-		g.stars[i] = Star{
-			X:     rand.Float64() * float64(g.screenWidth),
-			Y:     rand.Float64() * float64(g.screenHeight),
-			Speed: 20 + rand.Float64()*50,
-			Size:  1 + rand.Float64()*2,
-			Color: color.RGBA{R: 200, G: 200, B: 200, A: uint8(100 + rand.Intn(155))},
-		}
+// reseed resets the random source for a new run
+func (g *Game) reseed() {
+	if !g.fixedSeed {
+		g.seed = time.Now().UnixNano()
 	}
+	g.rng = rand.New(rand.NewSource(g.seed))
+}
+
+// collisionLayers returns the game's collision rules: the defaults, plus
+// enemy bullets hitting the player instead of other enemies, and
+// power-ups only touching the player who collects them
+func collisionLayers() *physics.LayerTable {
+	table := physics.DefaultLayerTable()
+	table.Enable(physics.LayerEnemyBullet, physics.LayerPlayer)
+	table.Disable(physics.LayerEnemyBullet, physics.LayerEnemy)
+	table.SetMask(physics.LayerPowerUp, physics.LayerPlayer)
+	return table
+}
+
+// resetBackground lays out a background for the run's seed, in the
+// level's theme or else the config's
+func (g *Game) resetBackground() {
+	theme := g.config.Background.Theme
+	if g.level != nil && g.level.Theme != "" {
+		theme = g.level.Theme
+	}
+	g.background = background.New(g.config.Background, theme, g.seed, g.screenWidth, g.screenHeight)
 }
 
 // startGame initializes a new game session
 func (g *Game) startGame() {
+	// Restart the random sequence so a seed always plays out the same way
+	g.reseed()
+	g.resetBackground()
+
 	g.player = entities.NewPlayer(
 		float64(g.screenWidth)/2,
 		float64(g.screenHeight)-100,
 		float64(g.screenWidth),
 		float64(g.screenHeight),
+		g.config.Player,
 	)
+	g.player.Animator = g.animator(assets.SpritePlayer)
 
 	g.enemies = g.enemies[:0]
 	g.bullets = g.bullets[:0]
 	g.particles = g.particles[:0]
+	g.wrecks = g.wrecks[:0]
+	g.powerUps = g.powerUps[:0]
+	g.laser = nil
+	g.boss = nil
+	g.bossWarning = 0
+	g.bossQueue = nil
+	g.waves = nil
+	g.wavesCleared = 0
+	if g.level != nil {
+		g.waves = level.NewRunner(g.level, float64(g.screenWidth), float64(g.screenHeight))
+	}
 	g.spawnTimer = 0
-	g.difficulty = 1.0
+	g.spawnInterval = g.config.Enemies.SpawnInterval
+	g.difficulty = director.NewDifficulty(g.config.Difficulty)
+	g.scorer = scoring.NewScorer(g.config.Scoring)
 	g.gameTime = 0
 
 	g.stateManager.SetState(engine.StatePlaying)
 }
 
+// SetLevel plays l's waves from the next run, then carries on in endless
+// mode. A nil level plays endless mode from the start. Every boss the
+// level brings in must be defined in the config.
+func (g *Game) SetLevel(l *level.Level) error {
+	if l != nil {
+		if err := l.CheckBosses(g.config); err != nil {
+			return err
+		}
+	}
+	g.level = l
+	g.resetBackground()
+	return nil
+}
+
+// SetHighScores records qualifying runs in table, saving it to path
+// after each one. An empty path keeps the table in memory only.
+func (g *Game) SetHighScores(table *highscore.Table, path string) {
+	g.scores = table
+	g.scoresPath = path
+}
+
+// layerKeys toggle the render layers, in the order of render.Layers
+var layerKeys = []ebiten.Key{ebiten.KeyF1, ebiten.KeyF2, ebiten.KeyF3, ebiten.KeyF4, ebiten.KeyF5}
+
 // Update updates the game state
 func (g *Game) Update() error {
-	dt := 1.0 / 60.0 // Fixed timestep
+	// Layer toggles only change what is drawn, so they are read straight
+	// from the keyboard rather than through the recorded input source
+	for i, key := range layerKeys {
+		if inpututil.IsKeyJustPressed(key) {
+			g.ToggleLayer(render.Layers[i])
+		}
+	}
+
+	dt := 1.0 / float64(g.config.Game.FPS) // Fixed timestep
+	g.step(dt)
+	return nil
+}
+
+// step advances the simulation by one tick
+func (g *Game) step(dt float64) {
+	g.tick++
+	g.prevActions = g.actions
+	g.actions = g.input.Poll()
 
 	// Handle state-specific input
 	g.handleInput()
@@ -120,72 +282,122 @@ func (g *Game) Update() error {
 		g.updatePlaying(dt)
 	case engine.StatePaused:
 		// No updates when paused
-	case engine.StateGameOver:
+	case engine.StateGameOver, engine.StateNameEntry:
 		g.updateGameOver(dt)
+	case engine.StateHighScores:
+		g.updateMenu(dt)
 	}
+	g.postfx.Update(dt)
+}
 
-	return nil
+// pressed reports whether an action started this tick
+func (g *Game) pressed(a input.Action) bool {
+	return g.actions.Has(a) && !g.prevActions.Has(a)
 }
 
 func (g *Game) handleInput() {
-	// Menu state
-	if g.stateManager.IsMenu() {
-		if ebiten.IsKeyPressed(ebiten.KeyEnter) {
+	switch g.stateManager.GetState() {
+	case engine.StateMenu:
+		if g.pressed(input.ActionConfirm) {
 			g.startGame()
+		} else if g.pressed(input.ActionScores) {
+			g.scoreRank = -1
+			g.stateManager.SetState(engine.StateHighScores)
 		}
-		if ebiten.IsKeyPressed(ebiten.KeyEscape) {
-			return
-		}
-	}
-
-	// Playing state
-	if g.stateManager.IsPlaying() {
-		if ebiten.IsKeyPressed(ebiten.KeyP) {
-			time.Sleep(200 * time.Millisecond) // Simple debounce
+	case engine.StatePlaying:
+		if g.pressed(input.ActionPause) {
 			g.stateManager.TogglePause()
-		}
-		if ebiten.IsKeyPressed(ebiten.KeyEscape) {
+		} else if g.pressed(input.ActionBack) {
 			g.stateManager.SetState(engine.StateMenu)
 		}
-	}
-
-	// Paused state
-	if g.stateManager.IsPaused() {
-		if ebiten.IsKeyPressed(ebiten.KeyP) {
-			time.Sleep(200 * time.Millisecond)
+	case engine.StatePaused:
+		if g.pressed(input.ActionPause) {
 			g.stateManager.TogglePause()
 		}
-	}
-
-	// Game over state
-	if g.stateManager.IsGameOver() {
-		if ebiten.IsKeyPressed(ebiten.KeyEnter) {
+	case engine.StateGameOver:
+		if g.pressed(input.ActionConfirm) {
 			g.startGame()
+		} else if g.pressed(input.ActionBack) {
+			g.stateManager.SetState(engine.StateMenu)
+		}
+	case engine.StateNameEntry:
+		switch {
+		case g.pressed(input.ActionUp):
+			g.nameEntry.Up()
+		case g.pressed(input.ActionDown):
+			g.nameEntry.Down()
+		case g.pressed(input.ActionLeft):
+			g.nameEntry.Left()
+		case g.pressed(input.ActionRight):
+			g.nameEntry.Right()
+		case g.pressed(input.ActionConfirm):
+			g.recordScore()
+			g.stateManager.SetState(engine.StateHighScores)
 		}
-		if ebiten.IsKeyPressed(ebiten.KeyEscape) {
+	case engine.StateHighScores:
+		if g.pressed(input.ActionConfirm) || g.pressed(input.ActionBack) {
 			g.stateManager.SetState(engine.StateMenu)
 		}
 	}
 }
 
+// endRun finishes the run, asking for a name if the score makes the
+// high-score table
+func (g *Game) endRun() {
+	g.scoreRank = -1
+	if g.scores != nil && g.scores.Qualifies(g.player.GetScore()) {
+		g.nameEntry = highscore.NewNameEntry(g.lastName)
+		g.stateManager.SetState(engine.StateNameEntry)
+		return
+	}
+	g.stateManager.SetState(engine.StateGameOver)
+}
+
+// recordScore adds the finished run to the high-score table under the
+// entered name and saves the table
+func (g *Game) recordScore() {
+	g.lastName = g.nameEntry.Name()
+	g.scoreRank = g.scores.Add(highscore.Entry{
+		Name:     g.lastName,
+		Score:    g.player.GetScore(),
+		Duration: g.gameTime,
+		Seed:     g.seed,
+		Date:     time.Now(),
+		Kills:    g.scorer.Kills(),
+	})
+
+	g.scoresErr = nil
+	if g.scoresPath != "" {
+		g.scoresErr = g.scores.Save(g.scoresPath)
+	}
+}
+
 func (g *Game) updateMenu(dt float64) {
-	g.updateStars(dt)
+	g.updateBackground(dt)
 }
 
 func (g *Game) updatePlaying(dt float64) {
 	g.gameTime += dt
 
-	// Update stars
-	g.updateStars(dt)
+	// Scroll the background
+	g.updateBackground(dt)
 
-	// Check game over first
+	// Lose a life and respawn, or end the game once lives run out
 	if g.player != nil && g.player.Health != nil && g.player.Health.IsDead() {
-		g.stateManager.SetState(engine.StateGameOver)
-		return
+		g.spawnExplosion(g.player.GetPosition())
+		g.spawnWreck(g.player.Animator, g.player.GetPosition(), g.player.GetVelocity(), g.player.Visual)
+		if !g.player.LoseLife() {
+			g.endRun()
+			return
+		}
+		g.player.Respawn(float64(g.screenWidth)/2, float64(g.screenHeight)-100)
 	}
 
-	// Update player
+	// Update player, noting its health to tell if it gets hurt this tick
+	health := 0
 	if g.player != nil {
+		health = g.player.Health.Current
+		g.player.HandleInput(g.actions)
 		if err := g.player.Update(dt); err != nil {
 			// Log error but continue game
 			_ = err
@@ -193,43 +405,91 @@ func (g *Game) updatePlaying(dt float64) {
 	}
 
 	// Handle player firing
-	if g.player.IsFiring() {
-		g.spawnPlayerBullet()
-		g.player.FireWeapon()
+	if g.player.Weapon.ProjectileType == entities.ProjectileLaser {
+		g.updateLaser(dt)
+	} else {
+		if g.laser != nil {
+			g.laser.SetActive(false)
+		}
+		if g.player.IsFiring() {
+			g.firePlayerWeapon()
+			g.player.FireWeapon()
+		}
 	}
 
 	// Update enemies
+	g.updateWorld()
 	g.updateEnemies(dt)
+	g.updateBoss(dt)
 
 	// Update bullets
 	g.updateBullets(dt)
 
-	// Update particles
+	// Update particles, wrecks and power-ups
 	g.updateParticles(dt)
-
-	// Spawn enemies
-	g.updateSpawning(dt)
+	g.updateWrecks(dt)
+	g.updatePowerUps(dt)
+
+	// Spawn enemies from the level's waves, then endlessly at random
+	if g.waves != nil && !g.waves.Done() {
+		g.updateWaves(dt)
+	} else {
+		g.updateSpawning(dt)
+	}
 
 	// Check collisions
 	g.checkCollisions()
+	g.checkGrazes()
 
-	// Increase difficulty over time
-	g.difficulty = 1.0 + g.gameTime/30.0
-	g.spawnInterval = 2.0 / g.difficulty
+	// Losing health breaks the combo and the wave's no-damage bonus
+	if g.player != nil && g.player.Health.Current < health {
+		g.scorer.Hurt()
+		g.postfx.Hit()
+	}
+	g.scorer.Update(dt)
+
+	// Adjust difficulty to time played and how the player is doing
+	g.updateDifficulty(dt)
+}
+
+func (g *Game) updateDifficulty(dt float64) {
+	g.difficulty.Update(dt, g.player.Health.GetPercentage())
+	g.spawnInterval = g.difficulty.SpawnInterval(g.config.Enemies.SpawnInterval)
 }
 
 func (g *Game) updateGameOver(dt float64) {
-	g.updateStars(dt)
+	g.updateBackground(dt)
 	g.updateParticles(dt)
+	g.updateWrecks(dt)
+}
+
+func (g *Game) updateBackground(dt float64) {
+	g.background.Update(dt, g.progress())
 }
 
-func (g *Game) updateStars(dt float64) {
-	for i := range g.stars {
-		g.stars[i].Y += g.stars[i].Speed * dt
-		if g.stars[i].Y > float64(g.screenHeight) {
-			g.stars[i].Y = 0
-			g.stars[i].X = rand.Float64() * float64(g.screenWidth)
+// progress returns how far through the run the player is, from 0 to 1:
+// the share of the level's waves cleared, or else time played over the
+// background's endless ramp
+func (g *Game) progress() float64 {
+	if g.waves != nil {
+		if g.waves.Done() {
+			return 1
 		}
+		return float64(g.waves.Cleared()) / float64(g.waves.Waves())
+	}
+	return g.gameTime / g.config.Background.EndlessRamp
+}
+
+// updateWorld refreshes what enemies know about the game this tick
+func (g *Game) updateWorld() {
+	g.world = entities.World{
+		Time:   g.gameTime,
+		Width:  float64(g.screenWidth),
+		Height: float64(g.screenHeight),
+	}
+	if g.player != nil && g.player.IsActive() {
+		g.world.Player = g.player.GetPosition()
+		g.world.HasPlayer = true
 	}
 }
 
@@ -241,6 +501,55 @@ func (g *Game) updateEnemies(dt float64) {
 			continue
 		}
 		_ = enemy.Update(dt)
+
+		if enemy.CanFire() && g.player != nil {
+			g.spawnEnemyBullets(enemy)
+		}
+	}
+}
+
+// updateBoss counts down the boss warning, then moves the boss and fires
+// its weapon until it is destroyed and the next boss in line comes in
+func (g *Game) updateBoss(dt float64) {
+	if g.boss == nil {
+		return
+	}
+	if g.bossWarning > 0 {
+		g.bossWarning -= dt
+		return
+	}
+	if !g.boss.IsActive() {
+		g.boss = nil
+		if len(g.bossQueue) > 0 {
+			next := g.bossQueue[0]
+			g.bossQueue = g.bossQueue[1:]
+			g.spawnBoss(next)
+		}
+		return
+	}
+
+	_ = g.boss.Update(dt)
+	if g.boss.PhaseChanged() {
+		g.spawnExplosion(g.boss.GetPosition())
+	}
+	if g.boss.CanFire() && g.player != nil {
+		g.spawnEnemyBullets(&g.boss.Enemy)
+	}
+}
+
+func (g *Game) spawnEnemyBullets(enemy *entities.Enemy) {
+	pos := enemy.GetPosition()
+	for _, velocity := range enemy.FireWeapon(g.player.GetPosition()) {
+		bullet := entities.NewBullet(
+			pos.X, pos.Y,
+			velocity,
+			enemy.Weapon.Damage,
+			entities.OwnerEnemy,
+			float64(g.screenWidth),
+			float64(g.screenHeight),
+		)
+		bullet.Animator = g.animator(assets.SpriteBulletEnemy)
+		g.bullets = append(g.bullets, bullet)
 	}
 }
 
@@ -251,10 +560,57 @@ func (g *Game) updateBullets(dt float64) {
 			g.bullets = append(g.bullets[:i], g.bullets[i+1:]...)
 			continue
 		}
+		if bullet.Projectile == entities.ProjectileMissile {
+			g.retarget(bullet)
+		}
 		_ = bullet.Update(dt)
 	}
 }
 
+// retarget points a missile at the nearest enemy
+func (g *Game) retarget(missile *entities.Bullet) {
+	missile.Target = nil
+
+	best := math.Inf(1)
+	pos := missile.GetPosition()
+	for _, enemy := range g.enemies {
+		if !enemy.IsActive() {
+			continue
+		}
+		if d := pos.DistanceSquared(enemy.GetPosition()); d < best {
+			best = d
+			missile.Target = enemy
+		}
+	}
+	for _, zone := range g.bossZones() {
+		if d := pos.DistanceSquared(zone.GetPosition()); d < best {
+			best = d
+			missile.Target = zone
+		}
+	}
+}
+
+// bossZones returns the zones of the boss in play, if any
+func (g *Game) bossZones() []*entities.BossZone {
+	if g.boss == nil || g.bossWarning > 0 || !g.boss.IsActive() {
+		return nil
+	}
+	return g.boss.Zones
+}
+
+// updateLaser keeps the beam on the ship's nose while fire is held
+func (g *Game) updateLaser(dt float64) {
+	weapon := g.player.Weapon
+	if g.laser == nil {
+		g.laser = entities.NewLaser(weapon.DamagePerSecond, weapon.BeamWidth)
+	}
+
+	pos := g.player.GetPosition()
+	g.laser.Aim(vector.New(pos.X, pos.Y-20))
+	g.laser.SetActive(g.player.TriggerHeld())
+	_ = g.laser.Update(dt)
+}
+
 func (g *Game) updateParticles(dt float64) {
 	for i := len(g.particles) - 1; i >= 0; i-- {
 		particle := g.particles[i]
@@ -266,6 +622,28 @@ func (g *Game) updateParticles(dt float64) {
 	}
 }
 
+func (g *Game) updateWrecks(dt float64) {
+	for i := len(g.wrecks) - 1; i >= 0; i-- {
+		wreck := g.wrecks[i]
+		if !wreck.IsActive() {
+			g.wrecks = append(g.wrecks[:i], g.wrecks[i+1:]...)
+			continue
+		}
+		_ = wreck.Update(dt)
+	}
+}
+
+func (g *Game) updatePowerUps(dt float64) {
+	for i := len(g.powerUps) - 1; i >= 0; i-- {
+		powerUp := g.powerUps[i]
+		if !powerUp.IsActive() {
+			g.powerUps = append(g.powerUps[:i], g.powerUps[i+1:]...)
+			continue
+		}
+		_ = powerUp.Update(dt)
+	}
+}
+
 func (g *Game) updateSpawning(dt float64) {
 	g.spawnTimer += dt
 	if g.spawnTimer >= g.spawnInterval {
@@ -274,29 +652,150 @@ func (g *Game) updateSpawning(dt float64) {
 	}
 }
 
+func (g *Game) updateWaves(dt float64) {
+	alive := g.aliveEnemies() + len(g.bossQueue)
+	if g.boss != nil {
+		alive++
+	}
+
+	for _, spawn := range g.waves.Update(dt, g.gameTime, alive) {
+		g.spawnFromLevel(spawn)
+	}
+
+	// Pay out the bonuses of each wave as it is cleared
+	if cleared := g.waves.Cleared(); cleared > g.wavesCleared {
+		g.wavesCleared = cleared
+		g.addScore(g.scorer.WaveCleared(vector.New(float64(g.screenWidth)/2, float64(g.screenHeight)/2)))
+	}
+}
+
+// spawnFromLevel creates an enemy a wave asked for
+func (g *Game) spawnFromLevel(spawn level.Spawn) {
+	if spawn.Boss != "" {
+		g.spawnBoss(spawn)
+		return
+	}
+
+	enemyType, ok := entities.ParseEnemyType(spawn.Type)
+	if !ok {
+		return
+	}
+	stats, _ := g.config.Enemies.EnemyType(spawn.Type)
+	if spawn.Movement != "" {
+		stats.Movement = spawn.Movement
+	}
+
+	enemy := entities.NewEnemy(enemyType, spawn.Position.X, spawn.Position.Y, float64(g.screenWidth), float64(g.screenHeight), stats)
+	if spawn.Formation != nil {
+		enemy.MovePattern = entities.PatternFormation
+		enemy.Behaviour = &entities.FormationFollow{Formation: spawn.Formation, Offset: spawn.Offset}
+	}
+	g.addEnemy(enemy)
+}
+
+// spawnBoss brings in a boss behind its warning, just above the screen.
+// While another boss is in play the new one waits its turn.
+func (g *Game) spawnBoss(spawn level.Spawn) {
+	if g.boss != nil {
+		g.bossQueue = append(g.bossQueue, spawn)
+		return
+	}
+
+	cfg, ok := g.config.Boss(spawn.Boss)
+	if !ok {
+		return
+	}
+
+	g.boss = entities.NewBoss(cfg, spawn.Position.X, -cfg.Height/2, float64(g.screenWidth), float64(g.screenHeight))
+	g.boss.World = &g.world
+	g.bossWarning = bossWarningTime
+}
+
 func (g *Game) spawnEnemy() {
-	enemy := entities.SpawnRandom(float64(g.screenWidth), float64(g.screenHeight))
+	if enemy := g.spawner.Spawn(g.rng, g.difficulty.Level(), g.aliveEnemies(), &g.world); enemy != nil {
+		g.addEnemy(enemy)
+	}
+}
+
+// aliveEnemies counts the enemies still in play
+func (g *Game) aliveEnemies() int {
+	alive := 0
+	for _, enemy := range g.enemies {
+		if enemy.IsActive() {
+			alive++
+		}
+	}
+	return alive
+}
+
+// addEnemy puts an enemy into play, letting it see the world and
+// toughening it to the current difficulty
+func (g *Game) addEnemy(enemy *entities.Enemy) {
+	enemy.Speed *= g.difficulty.EnemySpeed()
+	if enemy.Weapon != nil {
+		enemy.Weapon.FireRate /= g.difficulty.FireRate()
+	}
+	enemy.World = &g.world
+	enemy.Animator = g.animator(assets.EnemySprite(enemy.EnemyType.String()))
 	g.enemies = append(g.enemies, enemy)
 }
 
-func (g *Game) spawnPlayerBullet() {
+// firePlayerWeapon fires one volley of the player's bullets or missiles
+func (g *Game) firePlayerWeapon() {
 	pos := g.player.GetPosition()
-	velocity := vector.New(0, -entities.PlayerBulletSpeed)
+	weapon := g.player.Weapon
+	velocity := vector.New(0, -weapon.BulletSpeed)
+
+	if weapon.ProjectileType == entities.ProjectileMissile {
+		missile := entities.NewMissile(
+			pos.X, pos.Y-20,
+			velocity,
+			weapon.Damage,
+			weapon.TurnRate,
+			float64(g.screenWidth),
+			float64(g.screenHeight),
+		)
+		missile.Animator = g.animator(assets.SpriteMissile)
+		g.bullets = append(g.bullets, missile)
+		g.difficulty.ShotFired()
+		g.scorer.ShotFired()
+	} else {
+		for _, dir := range weapon.Directions(pos, pos) {
+			g.spawnPlayerBullet(pos, dir.Mul(weapon.BulletSpeed))
+		}
+	}
 
+	// Add trail particles
+	for i := 0; i < g.config.Particles.TrailFrequency; i++ {
+		g.addParticles(entities.CreateTrail(g.rng, pos.X, pos.Y, velocity))
+	}
+}
+
+func (g *Game) spawnPlayerBullet(pos, velocity vector.Vector2) {
 	bullet := entities.NewBullet(
 		pos.X, pos.Y-20,
 		velocity,
-		entities.PlayerBulletDamage,
+		g.player.Weapon.Damage,
 		entities.OwnerPlayer,
 		float64(g.screenWidth),
 		float64(g.screenHeight),
 	)
-
+	bullet.Animator = g.animator(assets.SpriteBulletPlayer)
 	g.bullets = append(g.bullets, bullet)
+	g.difficulty.ShotFired()
+	g.scorer.ShotFired()
+}
 
-	// Add trail particle
-	trail := entities.CreateTrail(pos.X, pos.Y, velocity)
-	g.particles = append(g.particles, trail)
+// addParticles adds particles up to the configured limit
+func (g *Game) addParticles(particles ...*entities.Particle) {
+	room := g.config.Particles.MaxParticles - len(g.particles)
+	if room <= 0 {
+		return
+	}
+	if len(particles) > room {
+		particles = particles[:room]
+	}
+	g.particles = append(g.particles, particles...)
 }
 
 func (g *Game) checkCollisions() {
@@ -310,11 +809,19 @@ func (g *Game) checkCollisions() {
 			g.collisionSystem.AddEntity(enemy)
 		}
 	}
+	for _, zone := range g.bossZones() {
+		g.collisionSystem.AddEntity(zone)
+	}
 	for _, bullet := range g.bullets {
 		if bullet.IsActive() {
 			g.collisionSystem.AddEntity(bullet)
 		}
 	}
+	for _, powerUp := range g.powerUps {
+		if powerUp.IsActive() {
+			g.collisionSystem.AddEntity(powerUp)
+		}
+	}
 
 	// Check collisions
 	collisions := g.collisionSystem.CheckCollisions()
@@ -323,6 +830,41 @@ func (g *Game) checkCollisions() {
 	for _, collision := range collisions {
 		g.handleCollision(collision.A, collision.B)
 	}
+
+	g.checkLaser()
+}
+
+// checkLaser damages every enemy along the laser beam
+func (g *Game) checkLaser() {
+	if g.laser == nil || !g.laser.IsActive() || g.laser.TickDamage() == 0 {
+		return
+	}
+
+	g.laserHits = g.collisionSystem.QuerySegment(
+		g.laser.GetPosition(),
+		g.laser.End(),
+		g.laser.GetRadius(),
+		physics.LayerPlayerBullet,
+		g.laserHits[:0],
+	)
+	// The beam hurts a boss once, through the zone it crosses that takes
+	// the most damage
+	var weakPoint *entities.BossZone
+	for _, hit := range g.laserHits {
+		switch target := hit.(type) {
+		case *entities.Enemy:
+			if target.IsActive() {
+				g.damageEnemy(target, g.laser.Hit())
+			}
+		case *entities.BossZone:
+			if weakPoint == nil || target.Multiplier > weakPoint.Multiplier {
+				weakPoint = target
+			}
+		}
+	}
+	if weakPoint != nil && weakPoint.IsActive() {
+		g.damageBoss(weakPoint, g.laser.Hit())
+	}
 }
 
 func (g *Game) handleCollision(a, b entities.Entity) {
@@ -331,19 +873,28 @@ func (g *Game) handleCollision(a, b entities.Entity) {
 		return
 	}
 
+	// A bullet only hits the first thing it touches
+	if !a.IsActive() || !b.IsActive() {
+		return
+	}
+
 	// Bullet vs Enemy
 	if a.GetType() == entities.TypeBullet && b.GetType() == entities.TypeEnemy {
 		bullet := a.(*entities.Bullet)
-		enemy := b.(*entities.Enemy)
 
 		if bullet.GetOwner() == entities.OwnerPlayer {
-			enemy.TakeDamage(bullet.GetDamage())
-			bullet.SetActive(false)
-
-			if !enemy.IsActive() && g.player != nil {
-				g.player.AddScore(enemy.ScoreValue)
-				g.spawnExplosion(enemy.GetPosition())
+			g.difficulty.ShotHit()
+			g.scorer.ShotHit()
+			switch target := b.(type) {
+			case *entities.Enemy:
+				if event := g.damageEnemy(target, bullet.Hit()); event.Shielded > 0 {
+					g.spawnHit(bullet.GetPosition())
+				}
+			case *entities.BossZone:
+				g.damageBoss(target, bullet.Hit())
+				g.spawnHit(bullet.GetPosition())
 			}
+			bullet.SetActive(false)
 		}
 	} else if a.GetType() == entities.TypeEnemy && b.GetType() == entities.TypeBullet {
 		g.handleCollision(b, a)
@@ -353,99 +904,403 @@ func (g *Game) handleCollision(a, b entities.Entity) {
 	// Player vs Enemy
 	if a.GetType() == entities.TypePlayer && b.GetType() == entities.TypeEnemy {
 		player := a.(*entities.Player)
-		enemy := b.(*entities.Enemy)
 
-		if player != nil && player.Health != nil {
-			player.Health.Damage(20)
+		// Invulnerable ships pass straight through
+		if player.Invulnerable() {
+			return
+		}
+		player.TakeDamage(contactDamage)
+		player.Protect(g.config.Player.HitInvulnerability)
+
+		// Enemies are destroyed by ramming them; bosses shrug it off
+		if enemy, ok := b.(*entities.Enemy); ok {
+			enemy.SetActive(false)
+			g.spawnExplosion(enemy.GetPosition())
+			g.spawnWreck(enemy.Animator, enemy.GetPosition(), enemy.GetVelocity(), enemy.Visual)
 		}
-		enemy.SetActive(false)
-		g.spawnExplosion(enemy.GetPosition())
 	} else if a.GetType() == entities.TypeEnemy && b.GetType() == entities.TypePlayer {
 		g.handleCollision(b, a)
 		return
 	}
+
+	// Enemy bullet vs Player
+	if a.GetType() == entities.TypeBullet && b.GetType() == entities.TypePlayer {
+		bullet := a.(*entities.Bullet)
+		player := b.(*entities.Player)
+
+		if bullet.GetOwner() == entities.OwnerEnemy && !player.Invulnerable() {
+			player.TakeDamage(bullet.GetDamage())
+			bullet.SetActive(false)
+			g.spawnHit(bullet.GetPosition())
+		}
+	} else if a.GetType() == entities.TypePlayer && b.GetType() == entities.TypeBullet {
+		g.handleCollision(b, a)
+		return
+	}
+
+	// Player vs PowerUp
+	if a.GetType() == entities.TypePlayer && b.GetType() == entities.TypePowerUp {
+		player := a.(*entities.Player)
+		powerUp := b.(*entities.PowerUp)
+
+		player.Collect(powerUp, g.config.PowerUps)
+		powerUp.SetActive(false)
+	} else if a.GetType() == entities.TypePowerUp && b.GetType() == entities.TypePlayer {
+		g.handleCollision(b, a)
+		return
+	}
+}
+
+// damageEnemy hits an enemy and scores it if it dies
+func (g *Game) damageEnemy(enemy *entities.Enemy, hit entities.Damage) entities.DamageEvent {
+	event := enemy.TakeDamage(hit)
+
+	if !enemy.IsActive() && g.player != nil {
+		g.difficulty.Kill(enemy.Time)
+		g.addScore(g.scorer.Kill(enemy.ScoreValue, enemy.GetPosition()))
+		g.spawnExplosion(enemy.GetPosition())
+		g.spawnWreck(enemy.Animator, enemy.GetPosition(), enemy.GetVelocity(), enemy.Visual)
+		g.dropPowerUp(enemy)
+	}
+	return event
+}
+
+// damageBoss hits a boss through one of its zones and scores it if it dies
+func (g *Game) damageBoss(zone *entities.BossZone, hit entities.Damage) entities.DamageEvent {
+	event := zone.TakeDamage(hit)
+
+	boss := zone.Boss
+	if !boss.IsActive() && g.player != nil {
+		g.addScore(g.scorer.Kill(boss.ScoreValue, boss.GetPosition()))
+		for _, z := range boss.Zones {
+			g.spawnExplosion(z.GetPosition())
+		}
+	}
+	return event
+}
+
+// addScore gives the player points the scorer has awarded, recording any
+// boost from a score power-up in the breakdown
+func (g *Game) addScore(points int) {
+	added := g.player.AddScore(points)
+	g.scorer.Record(scoring.SourcePowerUp, added-points)
+}
+
+// checkGrazes awards enemy bullets that pass close to the player without
+// hitting, once each as they leave the graze radius
+func (g *Game) checkGrazes() {
+	if g.player == nil || !g.player.IsActive() || g.player.Invulnerable() {
+		return
+	}
+
+	pos := g.player.GetPosition()
+	for _, bullet := range g.bullets {
+		if !bullet.IsActive() || bullet.Owner != entities.OwnerEnemy || bullet.Grazed {
+			continue
+		}
+		reach := g.player.GetRadius() + bullet.GetRadius() + g.config.Scoring.GrazeRadius
+		near := pos.DistanceSquared(bullet.GetPosition()) < reach*reach
+		if near {
+			bullet.Grazing = true
+		} else if bullet.Grazing {
+			bullet.Grazed = true
+			g.addScore(g.scorer.Graze(bullet.GetPosition()))
+		}
+	}
+}
+
+// dropPowerUp rolls the enemy type's drop table
+func (g *Game) dropPowerUp(enemy *entities.Enemy) {
+	stats, _ := g.config.Enemies.EnemyType(enemy.EnemyType.String())
+	kind, ok := entities.RollPowerUp(g.rng, stats)
+	if !ok {
+		return
+	}
+
+	pos := enemy.GetPosition()
+	powerUp := entities.NewPowerUp(kind, pos.X, pos.Y, g.config.PowerUps.FallSpeed, float64(g.screenHeight))
+	g.powerUps = append(g.powerUps, powerUp)
+}
+
+// spawnHit adds a small burst of particles where a bullet struck
+func (g *Game) spawnHit(pos vector.Vector2) {
+	hit := entities.CreateExplosion(g.rng, pos.X, pos.Y, g.config.Particles.ExplosionCount/4)
+	g.addParticles(hit...)
 }
 
 func (g *Game) spawnExplosion(pos vector.Vector2) {
-	explosion := entities.CreateExplosion(pos.X, pos.Y, 20)
-	g.particles = append(g.particles, explosion...)
+	explosion := entities.CreateExplosion(g.rng, pos.X, pos.Y, g.config.Particles.ExplosionCount)
+	g.addParticles(explosion...)
+}
+
+// spawnWreck leaves a destroyed ship's wreck playing its death animation
+func (g *Game) spawnWreck(animator *entities.Animator, pos, velocity vector.Vector2, visual *entities.Visual) {
+	if wreck := entities.NewWreck(animator, pos, velocity, visual); wreck != nil {
+		g.wrecks = append(g.wrecks, wreck)
+	}
+}
+
+// animator creates an animator for the named sprite, or nil if there is
+// no such sprite and the entity should draw as a plain shape
+func (g *Game) animator(sprite string) *entities.Animator {
+	return entities.NewAnimator(g.sprites.Sprite(sprite))
 }
 
-// Draw draws the game
+// Draw draws the game, queuing everything by layer and drawing the queue
 func (g *Game) Draw(screen *ebiten.Image) {
-	// Clear screen
-	screen.Fill(color.RGBA{R: 10, G: 10, B: 20, A: 255})
+	// The background is behind every screen, and the game behind every
+	// screen but the menus
+	g.background.Submit(g.queue)
+	switch g.stateManager.GetState() {
+	case engine.StateMenu, engine.StateHighScores:
+	default:
+		g.queueScene(g.queue)
+	}
+	g.queue.Submit(render.LayerUI, zOverlay, g.overlay)
+
+	// Effects apply to the play; the HUD and menus go on top untouched
+	scene := g.postfx.Begin(screen)
+	g.queue.DrawLayers(scene, render.LayerBackground, render.LayerEffects)
+	g.postfx.End(screen)
+	g.queue.DrawLayers(screen, render.LayerUI, render.LayerUI)
+}
+
+// Depths within the UI layer, back to front
+const (
+	zHUD = iota
+	zDebug
+	zOverlay
+)
+
+// newScene lists what makes up the game in play. Each source queues its
+// drawables in their own layer and depth, so new kinds of entity join the
+// scene by adding a source here.
+func (g *Game) newScene() []render.Source {
+	return []render.Source{
+		render.At(render.LayerGameplay, entities.ZParticles, render.DrawFunc(g.drawParticles)),
+		render.SourceFunc(func(q *render.Queue) { submitActive(q, g.wrecks) }),
+		render.SourceFunc(func(q *render.Queue) { submitActive(q, g.bullets) }),
+		render.SourceFunc(func(q *render.Queue) {
+			if g.laser != nil && g.laser.IsActive() {
+				g.laser.Submit(q)
+			}
+		}),
+		render.SourceFunc(func(q *render.Queue) { submitActive(q, g.enemies) }),
+		render.SourceFunc(func(q *render.Queue) {
+			if g.boss != nil && g.bossWarning <= 0 && g.boss.IsActive() {
+				g.boss.Submit(q)
+			}
+		}),
+		render.SourceFunc(func(q *render.Queue) { submitActive(q, g.powerUps) }),
+		render.SourceFunc(func(q *render.Queue) {
+			if g.player != nil && g.player.IsActive() {
+				g.player.Submit(q)
+			}
+		}),
+		render.At(render.LayerEffects, 0, render.DrawFunc(g.drawPopups)),
+		render.At(render.LayerUI, zHUD, render.DrawFunc(g.drawHUD)),
+		render.At(render.LayerUI, zDebug, render.DrawFunc(g.drawDebug)),
+	}
+}
+
+// submitActive queues each active entity for drawing
+func submitActive[T entities.Renderable](q *render.Queue, items []T) {
+	for _, item := range items {
+		if item.IsActive() {
+			item.Submit(q)
+		}
+	}
+}
 
-	// Draw stars
-	g.drawStars(screen)
+// queueScene queues everything in the scene
+func (g *Game) queueScene(q *render.Queue) {
+	for _, source := range g.scene {
+		source.Submit(q)
+	}
+}
 
-	// Draw based on state
+// ToggleLayer hides a render layer, or shows it again if hidden
+func (g *Game) ToggleLayer(layer render.Layer) {
+	g.queue.Toggle(layer)
+}
+
+// ToggleEffect turns the named post-processing effect off, or on again
+func (g *Game) ToggleEffect(name string) {
+	g.postfx.Toggle(name)
+}
+
+// drawOverlay draws the screen for the current state over everything else
+func (g *Game) drawOverlay(screen *ebiten.Image) {
 	switch g.stateManager.GetState() {
 	case engine.StateMenu:
 		g.ui.DrawMenu(screen)
-	case engine.StatePlaying:
-		g.drawGame(screen)
 	case engine.StatePaused:
-		g.drawGame(screen)
 		g.ui.DrawPauseMenu(screen)
 	case engine.StateGameOver:
-		g.drawGame(screen)
-		g.ui.DrawGameOver(screen, g.player.GetScore())
+		g.ui.DrawGameOver(screen, g.player.GetScore(), g.scoreLines(), g.seed)
+	case engine.StateNameEntry:
+		g.ui.DrawNameEntry(screen, g.player.GetScore(), g.scoreLines(), g.nameEntry.Name(), g.nameEntry.Cursor())
+	case engine.StateHighScores:
+		g.ui.DrawHighScores(screen, g.highScoreRows(), g.scoreRank, g.scoresErr)
 	}
 }
 
-func (g *Game) drawStars(screen *ebiten.Image) {
-	for _, star := range g.stars {
-		img := ebiten.NewImage(int(star.Size), int(star.Size))
-		img.Fill(star.Color)
-		op := &ebiten.DrawImageOptions{}
-		op.GeoM.Translate(star.X, star.Y)
-		screen.DrawImage(img, op)
+// quadBatch returns the batch particles are drawn with, created
+// on first draw once a graphics context exists
+func (g *Game) quadBatch() *render.Batch {
+	if g.batch == nil {
+		g.batch = render.NewBatch(render.SharedAtlas().White())
 	}
+	return g.batch
 }
 
-func (g *Game) drawGame(screen *ebiten.Image) {
-	// Draw particles (behind)
+// drawParticles draws every particle in one batch
+func (g *Game) drawParticles(screen *ebiten.Image) {
+	batch := g.quadBatch()
 	for _, particle := range g.particles {
 		if particle.IsActive() {
-			particle.Draw(screen)
+			particle.Batch(batch)
 		}
 	}
+	batch.Flush(screen)
+}
 
-	// Draw bullets
-	for _, bullet := range g.bullets {
-		if bullet.IsActive() {
-			bullet.Draw(screen)
-		}
+// drawPopups draws the score popups floating over the play
+func (g *Game) drawPopups(screen *ebiten.Image) {
+	for _, popup := range g.scorer.Popups() {
+		g.ui.DrawPopup(screen, popup.Text, int(popup.Position.X), int(popup.Position.Y))
 	}
+}
 
-	// Draw enemies
-	for _, enemy := range g.enemies {
-		if enemy.IsActive() {
-			enemy.Draw(screen)
+// drawHUD draws the HUD, wave banners and the boss's warning or health
+func (g *Game) drawHUD(screen *ebiten.Image) {
+	if g.player != nil {
+		g.ui.DrawHUD(screen, g.hud())
+	}
+	if g.waves != nil {
+		if banner, ok := g.waves.Banner(); ok {
+			g.ui.DrawBanner(screen, banner)
+		}
+	}
+	if g.boss != nil {
+		if g.bossWarning > 0 {
+			g.ui.DrawBossWarning(screen, g.boss.Name, bossWarningTime-g.bossWarning)
+		} else if g.boss.IsActive() {
+			g.ui.DrawBossBar(screen, g.boss.Name, g.boss.Health.GetPercentage(), g.boss.Defence.ShieldFraction())
 		}
 	}
+}
 
-	// Draw player
-	if g.player != nil && g.player.IsActive() {
-		g.player.Draw(screen)
+// hud collects what the HUD shows about the player
+func (g *Game) hud() ui.HUD {
+	hud := ui.HUD{
+		Score:      g.player.GetScore(),
+		Health:     g.player.Health.Current,
+		Lives:      g.player.Lives,
+		Difficulty: g.difficulty.Level(),
+		Combo:      g.scorer.Combo(),
+		Multiplier: g.scorer.Multiplier(),
 	}
+	if g.waves != nil && !g.waves.Done() {
+		hud.Wave = fmt.Sprintf("WAVE %d/%d", g.waves.Wave(), g.waves.Waves())
+	} else if g.level != nil {
+		hud.Wave = "ENDLESS"
+	}
+	for _, effect := range entities.TimedEffects {
+		if !g.player.Effects.Active(effect) {
+			continue
+		}
+		label := effect.String()
+		if effect == entities.EffectWeapon {
+			label = strings.ToUpper(g.player.Weapon.ProjectileType.String())
+		}
+		hud.Effects = append(hud.Effects, ui.ActiveEffect{
+			Label:     label,
+			Remaining: g.player.Effects.Remaining(effect),
+		})
+	}
+	return hud
+}
 
-	// Draw HUD
-	if g.player != nil {
-		g.ui.DrawHUD(screen, g.player.GetScore(), g.player.Health.Current)
+// scoreLines lists where the run's points came from for the game over
+// and name entry screens, leaving out sources that earned nothing
+func (g *Game) scoreLines() []ui.ScoreLine {
+	breakdown := g.scorer.Breakdown()
+	lines := make([]ui.ScoreLine, 0, len(scoring.Sources))
+	for _, source := range scoring.Sources {
+		if points := breakdown[source]; points != 0 {
+			lines = append(lines, ui.ScoreLine{Label: source.String(), Points: points})
+		}
 	}
+	return lines
+}
 
-	// Draw debug info
-	g.drawDebug(screen)
+// highScoreRows lists the high-score table for the high-score screen
+func (g *Game) highScoreRows() []ui.HighScore {
+	if g.scores == nil {
+		return nil
+	}
+	rows := make([]ui.HighScore, len(g.scores.Entries))
+	for i, e := range g.scores.Entries {
+		rows[i] = ui.HighScore{Name: e.Name, Score: e.Score, Kills: e.Kills, Duration: e.Duration, Date: e.Date}
+	}
+	return rows
 }
 
 func (g *Game) drawDebug(screen *ebiten.Image) {
 	debug := fmt.Sprintf("Enemies: %d | Bullets: %d | Particles: %d",
 		len(g.enemies), len(g.bullets), len(g.particles))
+
+	var hidden []string
+	for _, layer := range render.Layers {
+		if g.queue.Hidden(layer) {
+			hidden = append(hidden, layer.String())
+		}
+	}
+	if len(hidden) > 0 {
+		debug += " | Hidden: " + strings.Join(hidden, ", ")
+	}
+	if g.postfx.Err() != nil {
+		debug += " | Effects unavailable"
+	}
 	ebitenutil.DebugPrint(screen, debug)
 }
 
+// State returns the current game state
+func (g *Game) State() engine.GameState {
+	return g.stateManager.GetState()
+}
+
+// Tick returns the number of ticks simulated since the game was created
+func (g *Game) Tick() uint64 {
+	return g.tick
+}
+
+// Seed returns the seed of the current run
+func (g *Game) Seed() int64 {
+	return g.seed
+}
+
+// Telemetry returns the difficulty director's view of the current run
+func (g *Game) Telemetry() director.Snapshot {
+	return g.difficulty.Snapshot()
+}
+
+// Scores returns where the current run's points have come from
+func (g *Game) Scores() scoring.Breakdown {
+	return g.scorer.Breakdown()
+}
+
+// Boss returns the boss in play or about to enter, if any
+func (g *Game) Boss() *entities.Boss {
+	return g.boss
+}
+
+// Player returns the current player, or nil before the first game starts
+func (g *Game) Player() *entities.Player {
+	return g.player
+}
+
 // Layout returns the game's screen size
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 	return g.screenWidth, g.screenHeight
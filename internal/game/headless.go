@@ -0,0 +1,46 @@
+package game
+
+import (
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/input"
+)
+
+// Headless runs the game simulation without a window or renderer.
+// It starts straight into play and advances with a fixed timestep,
+// so it can be driven from tests or a server.
+type Headless struct {
+	game *Game
+	dt   float64
+}
+
+// NewHeadless creates a headless simulation that is already playing
+func NewHeadless(cfg *config.Config, src input.Source, seed int64) (*Headless, error) {
+	g, err := NewGame(cfg, src, seed)
+	if err != nil {
+		return nil, err
+	}
+	g.startGame()
+
+	return &Headless{
+		game: g,
+		dt:   1.0 / float64(g.config.Game.FPS),
+	}, nil
+}
+
+// Step advances the simulation by one tick
+func (h *Headless) Step() {
+	h.game.step(h.dt)
+}
+
+// Run advances the simulation by the given number of ticks,
+// stopping early if the game ends
+func (h *Headless) Run(ticks int) {
+	for i := 0; i < ticks && !h.game.stateManager.IsGameOver(); i++ {
+		h.Step()
+	}
+}
+
+// Game returns the simulated game
+func (h *Headless) Game() *Game {
+	return h.game
+}
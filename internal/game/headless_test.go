@@ -0,0 +1,771 @@
+package game
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/engine"
+	"github.com/EchoSingh/space-shooter/internal/entities"
+	"github.com/EchoSingh/space-shooter/internal/highscore"
+	"github.com/EchoSingh/space-shooter/internal/input"
+	"github.com/EchoSingh/space-shooter/internal/level"
+	"github.com/EchoSingh/space-shooter/internal/postfx"
+	"github.com/EchoSingh/space-shooter/internal/render"
+	"github.com/EchoSingh/space-shooter/internal/scoring"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+// newTestGame starts a headless game from cfg with no input and random
+// spawns held off, so tests decide what is in play
+func newTestGame(t *testing.T, cfg *config.Config) (*Headless, *Game) {
+	t.Helper()
+	cfg.Enemies.SpawnInterval = 1000
+	h, err := NewHeadless(cfg, input.NewScript(), 1)
+	if err != nil {
+		t.Fatalf("NewHeadless failed: %v", err)
+	}
+	return h, h.Game()
+}
+
+func TestHeadlessStartsPlaying(t *testing.T) {
+	_, g := newTestGame(t, config.Default())
+
+	if g.State() != engine.StatePlaying {
+		t.Errorf("Expected playing state, got %v", g.State())
+	}
+	if g.Player() == nil {
+		t.Fatal("Expected a player")
+	}
+}
+
+func TestHeadlessScriptedMovement(t *testing.T) {
+	script := input.NewScript().Hold(input.ActionLeft, 30)
+	h, err := NewHeadless(config.Default(), script, 1)
+	if err != nil {
+		t.Fatalf("NewHeadless failed: %v", err)
+	}
+
+	start := h.Game().Player().GetPosition()
+	h.Run(30)
+
+	if h.Game().Player().GetPosition().X >= start.X {
+		t.Error("Player should move left while left is held")
+	}
+}
+
+func TestHeadlessFiringAndSpawning(t *testing.T) {
+	script := input.NewScript().Hold(input.ActionFire, 600)
+	h, err := NewHeadless(config.Default(), script, 1)
+	if err != nil {
+		t.Fatalf("NewHeadless failed: %v", err)
+	}
+
+	h.Run(15)
+	if len(h.Game().bullets) == 0 {
+		t.Error("Expected bullets while fire is held")
+	}
+
+	h.Run(585)
+	if len(h.Game().enemies) == 0 && h.Game().player.GetScore() == 0 {
+		t.Error("Expected enemies to spawn over ten seconds")
+	}
+}
+
+func TestHeadlessPauseToggle(t *testing.T) {
+	script := input.NewScript(input.ActionPause, input.None, input.ActionPause)
+	h, err := NewHeadless(config.Default(), script, 1)
+	if err != nil {
+		t.Fatalf("NewHeadless failed: %v", err)
+	}
+
+	h.Step()
+	if h.Game().State() != engine.StatePaused {
+		t.Fatalf("Expected paused state, got %v", h.Game().State())
+	}
+
+	h.Step()
+	if h.Game().State() != engine.StatePaused {
+		t.Error("Releasing pause should not resume")
+	}
+
+	h.Step()
+	if h.Game().State() != engine.StatePlaying {
+		t.Errorf("Expected playing state after second press, got %v", h.Game().State())
+	}
+}
+
+func TestNewGameRequiresInput(t *testing.T) {
+	if _, err := NewGame(config.Default(), nil, 1); err == nil {
+		t.Error("Expected an error without an input source")
+	}
+}
+
+func TestSameSeedIsDeterministic(t *testing.T) {
+	run := func(seed int64) (int, int, []float64) {
+		script := input.NewScript().Hold(input.ActionFire|input.ActionLeft, 120).Hold(input.ActionFire|input.ActionRight, 1200)
+		h, err := NewHeadless(config.Default(), script, seed)
+		if err != nil {
+			t.Fatalf("NewHeadless failed: %v", err)
+		}
+		h.Run(1320)
+
+		g := h.Game()
+		xs := make([]float64, 0, len(g.enemies))
+		for _, e := range g.enemies {
+			xs = append(xs, e.GetPosition().X)
+		}
+		return g.player.GetScore(), g.player.Health.Current, xs
+	}
+
+	score1, health1, xs1 := run(42)
+	score2, health2, xs2 := run(42)
+
+	if score1 != score2 || health1 != health2 {
+		t.Errorf("Same seed diverged: score %d vs %d, health %d vs %d", score1, score2, health1, health2)
+	}
+	if len(xs1) != len(xs2) {
+		t.Fatalf("Same seed spawned %d vs %d enemies", len(xs1), len(xs2))
+	}
+	for i := range xs1 {
+		if xs1[i] != xs2[i] {
+			t.Errorf("Enemy %d at x=%g vs x=%g", i, xs1[i], xs2[i])
+		}
+	}
+}
+
+func TestSeedIsKeptAcrossRuns(t *testing.T) {
+	g, err := NewGame(config.Default(), input.NewScript(), 7)
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+
+	g.startGame()
+	g.startGame()
+
+	if g.Seed() != 7 {
+		t.Errorf("Expected seed 7, got %d", g.Seed())
+	}
+}
+
+func TestEnemyBulletsDamagePlayer(t *testing.T) {
+	cfg := config.Default()
+	h, g := newTestGame(t, cfg)
+	stats, _ := cfg.Enemies.EnemyType(config.EnemyShooter)
+	pos := g.player.GetPosition()
+	shooter := entities.NewEnemy(entities.EnemyShooter, pos.X, 100, float64(g.screenWidth), float64(g.screenHeight), stats)
+	g.enemies = append(g.enemies, shooter)
+
+	start := g.player.Health.Current
+	for i := 0; i < 300 && g.player.Health.Current == start; i++ {
+		h.Step()
+	}
+
+	if g.player.Health.Current != start-stats.BulletDamage {
+		t.Errorf("Expected an enemy bullet to deal %d damage, health went %d -> %d", stats.BulletDamage, start, g.player.Health.Current)
+	}
+}
+
+func newWeaponGame(t *testing.T, projectile string, script *input.Script) *Headless {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Player.Projectile = projectile
+	h, g := newTestGame(t, cfg)
+	g.input = script
+	return h
+}
+
+func TestSpreadFiresFan(t *testing.T) {
+	h := newWeaponGame(t, config.ProjectileSpread, input.NewScript().Hold(input.ActionFire, 15))
+	h.Run(15)
+
+	if n := len(h.Game().bullets); n != config.Default().Player.Spread.Count {
+		t.Errorf("Expected one fan of %d bullets, got %d", config.Default().Player.Spread.Count, n)
+	}
+}
+
+func TestMissilesHomeOnEnemies(t *testing.T) {
+	h := newWeaponGame(t, config.ProjectileMissile, input.NewScript().Hold(input.ActionFire, 30))
+	g := h.Game()
+
+	stats, _ := g.config.Enemies.EnemyType(config.EnemyTank)
+	pos := g.player.GetPosition()
+	tank := entities.NewEnemy(entities.EnemyTank, pos.X+150, 150, float64(g.screenWidth), float64(g.screenHeight), stats)
+	tank.Speed = 0
+	g.enemies = append(g.enemies, tank)
+
+	for i := 0; i < 240 && tank.Health.Current == stats.Health; i++ {
+		h.Step()
+	}
+
+	if tank.Health.Current == stats.Health {
+		t.Error("Expected a missile to home in on an enemy off to the side")
+	}
+}
+
+func TestLaserDamagesEverythingInLine(t *testing.T) {
+	h := newWeaponGame(t, config.ProjectileLaser, input.NewScript().Hold(input.ActionFire, 30))
+	g := h.Game()
+
+	stats, _ := g.config.Enemies.EnemyType(config.EnemyTank)
+	pos := g.player.GetPosition()
+	var tanks []*entities.Enemy
+	for _, y := range []float64{100, 250} {
+		tank := entities.NewEnemy(entities.EnemyTank, pos.X, y, float64(g.screenWidth), float64(g.screenHeight), stats)
+		tank.Speed = 0
+		tanks = append(tanks, tank)
+		g.enemies = append(g.enemies, tank)
+	}
+
+	h.Run(30)
+
+	if len(g.bullets) != 0 {
+		t.Errorf("Laser should not fire bullets, got %d", len(g.bullets))
+	}
+	for i, tank := range tanks {
+		if tank.Health.Current >= stats.Health {
+			t.Errorf("Expected tank %d to be burned by the laser", i)
+		}
+	}
+}
+
+func TestLaserRespectsResistance(t *testing.T) {
+	h := newWeaponGame(t, config.ProjectileLaser, input.NewScript().Hold(input.ActionFire, 60))
+	g := h.Game()
+
+	stats, _ := g.config.Enemies.EnemyType(config.EnemyTank)
+	stats.Health = 10000
+	stats.Defence = config.DefenceConfig{}
+	resistant := stats
+	resistant.Defence.Resistances = map[string]float64{config.DamageEnergy: 0.5}
+
+	pos := g.player.GetPosition()
+	plain := entities.NewEnemy(entities.EnemyTank, pos.X, 100, float64(g.screenWidth), float64(g.screenHeight), stats)
+	tough := entities.NewEnemy(entities.EnemyTank, pos.X, 250, float64(g.screenWidth), float64(g.screenHeight), resistant)
+	for _, tank := range []*entities.Enemy{plain, tough} {
+		tank.Speed = 0
+		g.enemies = append(g.enemies, tank)
+	}
+
+	h.Run(60)
+
+	plainDamage := stats.Health - plain.Health.Current
+	toughDamage := stats.Health - tough.Health.Current
+	if plainDamage == 0 || toughDamage < plainDamage/2-1 || toughDamage > plainDamage/2+1 {
+		t.Errorf("Expected a tank resisting half of the laser to take half of %d damage, got %d", plainDamage, toughDamage)
+	}
+}
+
+func TestPlayerCollectsPowerUps(t *testing.T) {
+	h, err := NewHeadless(config.Default(), input.NewScript(), 1)
+	if err != nil {
+		t.Fatalf("NewHeadless failed: %v", err)
+	}
+
+	g := h.Game()
+	pos := g.player.GetPosition()
+	g.powerUps = append(g.powerUps, entities.NewPowerUp(entities.PowerUpShield, pos.X, pos.Y-30, 80, float64(g.screenHeight)))
+
+	h.Run(30)
+
+	if len(g.powerUps) != 0 {
+		t.Error("Expected the power-up to be collected")
+	}
+	if !g.player.Effects.Active(entities.EffectShield) {
+		t.Error("Expected the shield to be active")
+	}
+	if hud := g.hud(); len(hud.Effects) != 1 || hud.Effects[0].Label != entities.EffectShield.String() {
+		t.Errorf("Expected the HUD to show the shield, got %+v", hud.Effects)
+	}
+}
+
+func TestKilledEnemiesDropPowerUps(t *testing.T) {
+	cfg := config.Default()
+	for i := range cfg.Enemies.Types {
+		cfg.Enemies.Types[i].DropChance = 1
+	}
+	h, err := NewHeadless(cfg, input.NewScript(), 1)
+	if err != nil {
+		t.Fatalf("NewHeadless failed: %v", err)
+	}
+
+	g := h.Game()
+	stats, _ := cfg.Enemies.EnemyType(config.EnemyBasic)
+	enemy := entities.NewEnemy(entities.EnemyBasic, 200, 200, float64(g.screenWidth), float64(g.screenHeight), stats)
+	g.damageEnemy(enemy, entities.Damage{Amount: stats.Health})
+
+	if len(g.powerUps) != 1 {
+		t.Fatalf("Expected a power-up drop, got %d", len(g.powerUps))
+	}
+	if kind := g.powerUps[0].Kind.String(); stats.Drops[kind] <= 0 {
+		t.Errorf("Dropped %s, which is not in the basic enemy's drop table", kind)
+	}
+}
+
+func TestDestroyedEnemiesLeaveWrecks(t *testing.T) {
+	cfg := config.Default()
+	h, g := newTestGame(t, cfg)
+	if g.player.Animator == nil {
+		t.Fatal("Expected the player to be animated")
+	}
+	stats, _ := cfg.Enemies.EnemyType(config.EnemyTank)
+	enemy := entities.NewEnemy(entities.EnemyTank, 200, 200, float64(g.screenWidth), float64(g.screenHeight), stats)
+	g.addEnemy(enemy)
+	if enemy.Animator == nil {
+		t.Fatal("Expected enemies to be animated")
+	}
+
+	g.damageEnemy(enemy, entities.Damage{Amount: stats.Health * 10})
+	if len(g.wrecks) != 1 {
+		t.Fatalf("Expected a wreck, got %d", len(g.wrecks))
+	}
+
+	h.Run(60)
+	if len(g.wrecks) != 0 {
+		t.Errorf("Expected the wreck gone once its death animation ended, got %d", len(g.wrecks))
+	}
+}
+
+func TestSceneQueuesByLayer(t *testing.T) {
+	cfg := config.Default()
+	_, g := newTestGame(t, cfg)
+	stats, _ := cfg.Enemies.EnemyType(config.EnemyBasic)
+	g.addEnemy(entities.NewEnemy(entities.EnemyBasic, 200, 200, float64(g.screenWidth), float64(g.screenHeight), stats))
+
+	q := render.NewQueue()
+	g.queueScene(q)
+	// Particles, the enemy and the player
+	if n := q.Len(render.LayerGameplay); n != 3 {
+		t.Errorf("Expected 3 gameplay drawables, got %d", n)
+	}
+	if q.Len(render.LayerEffects) != 1 || q.Len(render.LayerUI) != 2 {
+		t.Errorf("Expected popups over the play and the HUD and debug text on top, got %d and %d",
+			q.Len(render.LayerEffects), q.Len(render.LayerUI))
+	}
+
+	g.ToggleLayer(render.LayerGameplay)
+	g.queueScene(g.queue)
+	if g.queue.Len(render.LayerGameplay) != 0 || g.queue.Len(render.LayerUI) == 0 {
+		t.Error("Expected only the hidden layer to be left out")
+	}
+}
+
+func TestDamageFlashesScreen(t *testing.T) {
+	cfg := config.Default()
+	h, g := newTestGame(t, cfg)
+	h.Step()
+	if g.postfx.Hitting() {
+		t.Fatal("Expected no flash before taking damage")
+	}
+
+	stats, _ := cfg.Enemies.EnemyType(config.EnemyTank)
+	pos := g.player.GetPosition()
+	g.addEnemy(entities.NewEnemy(entities.EnemyTank, pos.X, pos.Y, float64(g.screenWidth), float64(g.screenHeight), stats))
+	h.Step()
+	if !g.postfx.Hitting() {
+		t.Fatal("Expected a flash after taking damage")
+	}
+	h.Run(int(cfg.PostFX.FlashTime*60) + 1)
+	if g.postfx.Hitting() {
+		t.Error("Expected the flash to fade")
+	}
+
+	g.ToggleEffect(postfx.CRT)
+	if !g.postfx.Enabled(postfx.CRT) {
+		t.Error("Expected the CRT effect toggled on")
+	}
+}
+
+func TestEnemiesSeeThePlayer(t *testing.T) {
+	cfg := config.Default()
+	h, g := newTestGame(t, cfg)
+	stats, _ := cfg.Enemies.EnemyType(config.EnemyBasic)
+	stats.Movement = config.MoveSeek
+	pos := g.player.GetPosition()
+	seeker := entities.NewEnemy(entities.EnemyBasic, pos.X-300, 100, float64(g.screenWidth), float64(g.screenHeight), stats)
+	g.addEnemy(seeker)
+
+	h.Run(60)
+
+	if !g.world.HasPlayer || g.world.Player != g.player.GetPosition() {
+		t.Errorf("Expected the world to track the player, got %+v", g.world)
+	}
+	if seeker.Velocity.X <= 0 {
+		t.Errorf("Expected the seeker to head for the player, velocity %v", seeker.Velocity)
+	}
+}
+
+func TestLevelWavesThenEndless(t *testing.T) {
+	l, err := level.Parse([]byte(`
+break: 0.5
+waves:
+  - clear: "timer"
+    duration: 1
+    enemies:
+      - type: "basic"
+        count: 3
+        formation: "line"
+`))
+	if err != nil {
+		t.Fatalf("level.Parse failed: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.Enemies.SpawnInterval = 0.5
+	g, err := NewGame(cfg, input.NewScript(), 1)
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+	if err := g.SetLevel(l); err != nil {
+		t.Fatalf("SetLevel failed: %v", err)
+	}
+	g.startGame()
+	h := &Headless{game: g, dt: 1.0 / 60}
+
+	h.Run(20)
+	if len(g.enemies) != 0 {
+		t.Fatalf("Expected no random spawns during the first break, got %d enemies", len(g.enemies))
+	}
+
+	h.Run(20)
+	if len(g.enemies) != 3 {
+		t.Fatalf("Expected the wave's 3 enemies, got %d", len(g.enemies))
+	}
+	for _, e := range g.enemies {
+		if e.MovePattern != entities.PatternFormation {
+			t.Errorf("Expected formation movement, got %v", e.MovePattern)
+		}
+	}
+
+	h.Run(180)
+	if !g.waves.Done() {
+		t.Fatal("Expected the level to be done")
+	}
+	if g.hud().Wave != "ENDLESS" {
+		t.Errorf("Expected the HUD to show endless mode, got %q", g.hud().Wave)
+	}
+
+	before := len(g.enemies)
+	h.Run(60)
+	if len(g.enemies) <= before {
+		t.Error("Expected random spawning to resume after the level")
+	}
+}
+
+func TestLevelPicksBackground(t *testing.T) {
+	l, err := level.Parse([]byte(`
+theme: "asteroid_field"
+break: 0.5
+waves:
+  - clear: "timer"
+    duration: 1
+    enemies:
+      - type: "basic"
+  - clear: "timer"
+    duration: 1
+    enemies:
+      - type: "basic"
+`))
+	if err != nil {
+		t.Fatalf("level.Parse failed: %v", err)
+	}
+
+	g, err := NewGame(config.Default(), input.NewScript(), 1)
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+	if g.background.Theme() != config.ThemeDeepSpace {
+		t.Errorf("Expected the config's theme without a level, got %s", g.background.Theme())
+	}
+	if err := g.SetLevel(l); err != nil {
+		t.Fatalf("SetLevel failed: %v", err)
+	}
+	g.startGame()
+	if g.background.Theme() != config.ThemeAsteroidField {
+		t.Errorf("Expected the level's theme, got %s", g.background.Theme())
+	}
+
+	if p := g.progress(); p != 0 {
+		t.Errorf("Expected no progress at the start, got %g", p)
+	}
+	h := &Headless{game: g, dt: 1.0 / 60}
+	for i := 0; i < 600 && g.waves.Cleared() == 0; i++ {
+		h.Step()
+	}
+	if p := g.progress(); p != 0.5 {
+		t.Errorf("Expected half way after the first of two waves, got %g", p)
+	}
+	for i := 0; i < 600 && !g.waves.Done(); i++ {
+		h.Step()
+	}
+	if p := g.progress(); p != 1 {
+		t.Errorf("Expected full progress once the level is done, got %g", p)
+	}
+}
+
+func TestBossWave(t *testing.T) {
+	l, err := level.Parse([]byte(`
+break: 0
+waves:
+  - enemies:
+      - boss: "dreadnought"
+`))
+	if err != nil {
+		t.Fatalf("level.Parse failed: %v", err)
+	}
+
+	cfg := config.Default()
+	g, err := NewGame(cfg, input.NewScript(), 1)
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+	if err := g.SetLevel(l); err != nil {
+		t.Fatalf("SetLevel failed: %v", err)
+	}
+	g.startGame()
+	h := &Headless{game: g, dt: 1.0 / 60}
+
+	h.Run(2)
+	boss := g.Boss()
+	if boss == nil || g.bossWarning <= 0 {
+		t.Fatal("Expected the boss warning to start with the wave")
+	}
+	if len(g.bossZones()) != 0 {
+		t.Error("The boss should not be hittable during its warning")
+	}
+
+	h.Run(int(bossWarningTime*60) + 120)
+	if len(g.bossZones()) == 0 || boss.GetPosition().Y <= 0 {
+		t.Fatalf("Expected the boss on screen after its warning, at %v", boss.GetPosition())
+	}
+
+	// The shield soaks up the first hits before health starts to fall
+	core := boss.Zones[len(boss.Zones)-1]
+	event := g.damageBoss(core, entities.Damage{Amount: 20})
+	if event.Shielded == 0 || event.Dealt != 0 {
+		t.Errorf("Expected the boss's shield to take the first hit, got %+v", event)
+	}
+	for i := 0; i < 100 && boss.Phase == 0; i++ {
+		g.damageBoss(core, entities.Damage{Amount: 40})
+		h.Step()
+	}
+	if boss.Phase != 1 {
+		t.Errorf("Expected the second phase as health fell, got phase %d", boss.Phase)
+	}
+
+	score := g.player.GetScore()
+	g.damageBoss(core, entities.Damage{Amount: boss.Health.Maximum})
+	if boss.IsActive() {
+		t.Fatal("Expected the boss to be destroyed")
+	}
+	if g.player.GetScore() != score+boss.ScoreValue {
+		t.Errorf("Expected the boss's %d points, score went %d -> %d", boss.ScoreValue, score, g.player.GetScore())
+	}
+
+	h.Run(2)
+	if g.Boss() != nil {
+		t.Error("Expected the destroyed boss to leave play")
+	}
+	if !g.waves.Done() {
+		t.Error("Expected the boss wave to clear")
+	}
+}
+
+func TestBossesWaitTheirTurn(t *testing.T) {
+	l, err := level.Parse([]byte(`
+break: 0
+waves:
+  - enemies:
+      - boss: "dreadnought"
+      - boss: "dreadnought"
+`))
+	if err != nil {
+		t.Fatalf("level.Parse failed: %v", err)
+	}
+
+	cfg := config.Default()
+	g, err := NewGame(cfg, input.NewScript(), 1)
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+	if err := g.SetLevel(l); err != nil {
+		t.Fatalf("SetLevel failed: %v", err)
+	}
+	g.startGame()
+	g.player.Effects.Start(entities.EffectShield, 1000)
+	h := &Headless{game: g, dt: 1.0 / 60}
+
+	h.Run(int(bossWarningTime*60) + 120)
+	first := g.Boss()
+	if first == nil || len(g.bossQueue) != 1 {
+		t.Fatalf("Expected one boss in play and one waiting, got %v and %d", first, len(g.bossQueue))
+	}
+
+	g.damageBoss(first.Zones[0], entities.Damage{Amount: first.Health.Maximum * 10})
+	h.Run(2)
+	second := g.Boss()
+	if second == nil || second == first || len(g.bossQueue) != 0 {
+		t.Fatalf("Expected the second boss to come in once the first was destroyed, got %v", second)
+	}
+	if g.waves.Done() {
+		t.Error("Expected the wave to wait for the second boss")
+	}
+
+	h.Run(int(bossWarningTime*60) + 120)
+	g.damageBoss(second.Zones[0], entities.Damage{Amount: second.Health.Maximum * 10})
+	h.Run(2)
+	if g.Boss() != nil || !g.waves.Done() {
+		t.Error("Expected the wave cleared with both bosses destroyed")
+	}
+}
+
+func TestDifficultyToughensNewEnemies(t *testing.T) {
+	cfg := config.Default()
+	cfg.Difficulty.IncreaseRate = 1
+	h, g := newTestGame(t, cfg)
+	h.Run(300)
+	if level := g.Telemetry().Level; level <= 1 || g.hud().Difficulty != level {
+		t.Fatalf("Expected the difficulty to rise and show on the HUD, got %g", level)
+	}
+
+	stats, _ := cfg.Enemies.EnemyType(config.EnemyShooter)
+	shooter := entities.NewEnemy(entities.EnemyShooter, 400, 100, float64(g.screenWidth), float64(g.screenHeight), stats)
+	g.addEnemy(shooter)
+
+	if shooter.Speed <= stats.Speed || shooter.Weapon.FireRate >= stats.FireRate {
+		t.Errorf("Expected a faster, quicker-firing shooter, got speed %g and fire rate %g", shooter.Speed, shooter.Weapon.FireRate)
+	}
+}
+
+func TestPlayerRespawnsUntilOutOfLives(t *testing.T) {
+	cfg := config.Default()
+	h, g := newTestGame(t, cfg)
+	for life := cfg.Player.Lives; life > 1; life-- {
+		g.player.Health.Damage(cfg.Player.Health)
+		h.Step()
+		if g.State() != engine.StatePlaying || g.player.Lives != life-1 {
+			t.Fatalf("Expected to respawn with %d lives, state %v with %d", life-1, g.State(), g.player.Lives)
+		}
+		if !g.player.Invulnerable() || g.hud().Lives != g.player.Lives {
+			t.Error("Expected a blinking respawn shown on the HUD")
+		}
+	}
+
+	g.player.Health.Damage(cfg.Player.Health)
+	h.Step()
+	if g.State() != engine.StateGameOver {
+		t.Errorf("Expected game over with no lives left, got %v", g.State())
+	}
+}
+
+func TestRammingGrantsInvulnerability(t *testing.T) {
+	cfg := config.Default()
+	h, g := newTestGame(t, cfg)
+	stats, _ := cfg.Enemies.EnemyType(config.EnemyTank)
+	stats.Speed = 1
+	pos := g.player.GetPosition()
+	for i := 0; i < 2; i++ {
+		g.addEnemy(entities.NewEnemy(entities.EnemyTank, pos.X, pos.Y, float64(g.screenWidth), float64(g.screenHeight), stats))
+	}
+
+	h.Step()
+	if got := cfg.Player.Health - g.player.Health.Current; got != contactDamage {
+		t.Errorf("Expected one ram to hurt, lost %d health", got)
+	}
+	if !g.player.Invulnerable() {
+		t.Error("Expected invulnerability after being rammed")
+	}
+	if g.aliveEnemies() != 1 {
+		t.Errorf("Expected the second enemy to pass through, %d left", g.aliveEnemies())
+	}
+}
+
+func TestQuickKillsChainIntoCombo(t *testing.T) {
+	cfg := config.Default()
+	h, g := newTestGame(t, cfg)
+	stats, _ := cfg.Enemies.EnemyType(config.EnemyBasic)
+	for i := 0; i < cfg.Scoring.ComboStep; i++ {
+		enemy := entities.NewEnemy(entities.EnemyBasic, 100, 100, float64(g.screenWidth), float64(g.screenHeight), stats)
+		g.damageEnemy(enemy, entities.Damage{Amount: stats.Health})
+		h.Step()
+	}
+
+	if g.hud().Multiplier != 2 {
+		t.Errorf("Expected x2 after %d quick kills, got x%d", cfg.Scoring.ComboStep, g.hud().Multiplier)
+	}
+	scores := g.Scores()
+	if scores.Total() != g.player.GetScore() || scores[scoring.SourceCombo] != stats.Score {
+		t.Errorf("Expected the last kill doubled and the breakdown to match score %d, got %+v", g.player.GetScore(), scores)
+	}
+}
+
+func TestGrazingEnemyBullets(t *testing.T) {
+	cfg := config.Default()
+	h, g := newTestGame(t, cfg)
+
+	// One bullet skims past the ship, another passes well clear
+	pos := g.player.GetPosition()
+	for _, offset := range []float64{g.player.GetRadius() + 10, 200} {
+		bullet := entities.NewBullet(pos.X+offset, pos.Y-100, vector.New(0, 200), 10, entities.OwnerEnemy, float64(g.screenWidth), float64(g.screenHeight))
+		g.bullets = append(g.bullets, bullet)
+	}
+	h.Run(120)
+
+	if g.player.Health.Current != cfg.Player.Health {
+		t.Fatalf("Expected neither bullet to hit, health %d", g.player.Health.Current)
+	}
+	if got := g.Scores()[scoring.SourceGraze]; got != cfg.Scoring.GrazePoints {
+		t.Errorf("Expected one graze worth %d, got %d", cfg.Scoring.GrazePoints, got)
+	}
+}
+
+func TestHighScoreNameEntry(t *testing.T) {
+	cfg := config.Default()
+	cfg.Player.Lives = 1
+	h, g := newTestGame(t, cfg)
+	path := filepath.Join(t.TempDir(), highscore.FileName)
+	g.SetHighScores(highscore.New(cfg.Game.HighScores), path)
+
+	g.player.AddScore(150)
+	g.player.Health.Damage(cfg.Player.Health)
+	h.Step()
+	if g.State() != engine.StateNameEntry {
+		t.Fatalf("Expected name entry for a qualifying score, got %v", g.State())
+	}
+
+	// Each press is let go for a tick so the next one registers
+	g.input = input.NewScript(
+		input.ActionUp, input.None,
+		input.ActionRight, input.None,
+		input.ActionDown, input.None,
+		input.ActionConfirm, input.None,
+	)
+	h.Run(8)
+	if g.State() != engine.StateHighScores || g.scoreRank != 0 {
+		t.Fatalf("Expected the high-score screen showing rank 0, got %v at %d", g.State(), g.scoreRank)
+	}
+
+	table, err := highscore.Load(path, cfg.Game.HighScores)
+	if err != nil {
+		t.Fatalf("Expected the table saved, got %v", err)
+	}
+	if len(table.Entries) != 1 || table.Entries[0].Name != "B A" || table.Entries[0].Score != 150 || table.Entries[0].Seed != g.Seed() {
+		t.Errorf("Expected the run saved as B A, got %+v", table.Entries)
+	}
+}
+
+func TestLowScoreSkipsNameEntry(t *testing.T) {
+	cfg := config.Default()
+	cfg.Player.Lives = 1
+	h, g := newTestGame(t, cfg)
+	table := highscore.New(1)
+	table.Add(highscore.Entry{Name: "TOP", Score: 1000})
+	g.SetHighScores(table, "")
+
+	g.player.AddScore(150)
+	g.player.Health.Damage(cfg.Player.Health)
+	h.Step()
+	if g.State() != engine.StateGameOver {
+		t.Errorf("Expected plain game over for a score off the table, got %v", g.State())
+	}
+}
@@ -0,0 +1,185 @@
+// Package highscore keeps the table of best runs in a JSON file under the
+// user's config directory.
+package highscore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// File layout:
+//
+//	{"version": 1, "entries": [{"name": ..., "score": ..., ...}, ...]}
+//
+// Entries are kept best first.
+const (
+	Version  = 1
+	FileName = "highscores.json"
+)
+
+// ErrCorrupt is returned when a high-score file can't be read. The
+// unreadable file is moved aside and an empty table is returned with it.
+var ErrCorrupt = errors.New("highscore: corrupt high-score file")
+
+// Entry is one run in the table
+type Entry struct {
+	Name     string    `json:"name"`
+	Score    int       `json:"score"`
+	Duration float64   `json:"duration"` // seconds played
+	Seed     int64     `json:"seed"`
+	Date     time.Time `json:"date"`
+	Kills    int       `json:"kills"`
+}
+
+type file struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// Table holds the best runs, up to its size
+type Table struct {
+	Entries []Entry
+	size    int
+}
+
+// New creates an empty table that keeps the best size runs
+func New(size int) *Table {
+	return &Table{size: size}
+}
+
+// FromScores creates a table that keeps the best size runs, holding
+// unnamed runs with the given scores. That is all a table needs to
+// decide which runs qualify, as when a replay is played back.
+func FromScores(size int, scores []int) *Table {
+	t := New(size)
+	for _, score := range scores {
+		t.Add(Entry{Score: score})
+	}
+	return t
+}
+
+// Scores returns the score of each run in the table, best first
+func (t *Table) Scores() []int {
+	scores := make([]int, len(t.Entries))
+	for i, e := range t.Entries {
+		scores[i] = e.Score
+	}
+	return scores
+}
+
+// Qualifies reports whether a run scoring score would make the table
+func (t *Table) Qualifies(score int) bool {
+	if score <= 0 {
+		return false
+	}
+	return len(t.Entries) < t.size || score > t.Entries[len(t.Entries)-1].Score
+}
+
+// Add puts a run into the table and returns its 0-based rank, or -1 if
+// it didn't make the cut. A run that ties an earlier one ranks below it.
+func (t *Table) Add(e Entry) int {
+	if !t.Qualifies(e.Score) {
+		return -1
+	}
+
+	rank := sort.Search(len(t.Entries), func(i int) bool {
+		return t.Entries[i].Score < e.Score
+	})
+	t.Entries = append(t.Entries, Entry{})
+	copy(t.Entries[rank+1:], t.Entries[rank:])
+	t.Entries[rank] = e
+	if len(t.Entries) > t.size {
+		t.Entries = t.Entries[:t.size]
+	}
+	return rank
+}
+
+// DefaultPath returns where the table is kept for the current user
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "space-shooter", FileName), nil
+}
+
+// Load reads a table of the given size from a file. A missing file gives
+// an empty table. A corrupt one is renamed with a .corrupt suffix so the
+// next save doesn't overwrite it, and an empty table is returned along
+// with an error wrapping ErrCorrupt.
+func Load(path string, size int) (*Table, error) {
+	t := New(size)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return t, err
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return t, quarantine(path, err)
+	}
+	if f.Version != Version {
+		return t, quarantine(path, fmt.Errorf("unsupported version %d", f.Version))
+	}
+
+	// Hand edits can leave the table out of order or overlong
+	for _, e := range f.Entries {
+		t.Add(e)
+	}
+	return t, nil
+}
+
+// quarantine moves an unreadable file out of the way
+func quarantine(path string, cause error) error {
+	if err := os.Rename(path, path+".corrupt"); err != nil {
+		return fmt.Errorf("%w %s: %v (could not move it aside: %v)", ErrCorrupt, path, cause, err)
+	}
+	return fmt.Errorf("%w %s: %v (moved to %s.corrupt)", ErrCorrupt, path, cause, path)
+}
+
+// Save writes the table to a file, creating its directory if needed. The
+// file is replaced atomically, so a crash mid-save leaves the old table.
+func (t *Table) Save(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(file{Version: Version, Entries: t.Entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+FileName+"-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package highscore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestAddKeepsBestRuns(t *testing.T) {
+	table := New(3)
+
+	for _, score := range []int{100, 300, 200} {
+		table.Add(Entry{Name: "AAA", Score: score})
+	}
+	if rank := table.Add(Entry{Name: "BBB", Score: 200}); rank != 2 {
+		t.Errorf("Expected a tie to rank below the earlier run, got rank %d", rank)
+	}
+	if rank := table.Add(Entry{Name: "CCC", Score: 50}); rank != -1 {
+		t.Errorf("Expected a low score to miss the table, got rank %d", rank)
+	}
+
+	want := []int{300, 200, 200}
+	if len(table.Entries) != len(want) {
+		t.Fatalf("Expected %d entries, got %d", len(want), len(table.Entries))
+	}
+	for i, e := range table.Entries {
+		if e.Score != want[i] {
+			t.Errorf("Entry %d: expected %d, got %d", i, want[i], e.Score)
+		}
+	}
+	if table.Entries[2].Name != "BBB" {
+		t.Errorf("Expected the newer tie last, got %s", table.Entries[2].Name)
+	}
+}
+
+func TestQualifies(t *testing.T) {
+	table := New(2)
+	if table.Qualifies(0) {
+		t.Error("A zero score should never qualify")
+	}
+	if !table.Qualifies(10) {
+		t.Error("Any score should qualify for an empty table")
+	}
+
+	table.Add(Entry{Score: 50})
+	table.Add(Entry{Score: 40})
+	if table.Qualifies(40) || !table.Qualifies(41) {
+		t.Error("A full table should only take scores beating its last run")
+	}
+}
+
+func TestFromScores(t *testing.T) {
+	table := FromScores(2, []int{40, 0, 90, 60})
+
+	if got := table.Scores(); !slices.Equal(got, []int{90, 60}) {
+		t.Errorf("Expected the best two scores, got %v", got)
+	}
+	if table.Qualifies(60) || !table.Qualifies(61) {
+		t.Error("Expected the table to qualify runs like the one it came from")
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", FileName)
+	table := New(5)
+	date := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	table.Add(Entry{Name: "ACE", Score: 1200, Duration: 95.5, Seed: 42, Date: date, Kills: 61})
+	table.Add(Entry{Name: "BOB", Score: 800, Duration: 60, Seed: 7, Date: date, Kills: 30})
+
+	if err := table.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(path, 5)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.Entries) != 2 || loaded.Entries[0] != table.Entries[0] || loaded.Entries[1] != table.Entries[1] {
+		t.Errorf("Expected %+v, got %+v", table.Entries, loaded.Entries)
+	}
+
+	// Only the table itself is left behind
+	files, _ := os.ReadDir(filepath.Dir(path))
+	if len(files) != 1 {
+		t.Errorf("Expected just the table file, got %d files", len(files))
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	table, err := Load(filepath.Join(t.TempDir(), FileName), 5)
+	if err != nil {
+		t.Fatalf("Expected no error for a missing file, got %v", err)
+	}
+	if len(table.Entries) != 0 || !table.Qualifies(1) {
+		t.Error("Expected an empty table")
+	}
+}
+
+func TestLoadRecoversFromCorruption(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"truncated", `{"version": 1, "entries": [{"name": "AC`},
+		{"future version", `{"version": 99, "entries": []}`},
+		{"not json", "\x00\x01garbage"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), FileName)
+			if err := os.WriteFile(path, []byte(tt.data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			table, err := Load(path, 5)
+			if !errors.Is(err, ErrCorrupt) {
+				t.Errorf("Expected ErrCorrupt, got %v", err)
+			}
+			if table == nil || len(table.Entries) != 0 {
+				t.Fatal("Expected an empty table to carry on with")
+			}
+
+			// The bad file is kept aside and a fresh table can be saved
+			if kept, err := os.ReadFile(path + ".corrupt"); err != nil || string(kept) != tt.data {
+				t.Errorf("Expected the corrupt file moved aside intact, got %q, %v", kept, err)
+			}
+			table.Add(Entry{Name: "NEW", Score: 10})
+			if err := table.Save(path); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+			if _, err := Load(path, 5); err != nil {
+				t.Errorf("Expected the new table to load, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadSortsAndTrims(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	data := `{"version": 1, "entries": [{"name": "A", "score": 10}, {"name": "B", "score": 30}, {"name": "C", "score": 20}, {"name": "D", "score": -5}]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := Load(path, 2)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(table.Entries) != 2 || table.Entries[0].Name != "B" || table.Entries[1].Name != "C" {
+		t.Errorf("Expected B then C, got %+v", table.Entries)
+	}
+}
+
+func TestNameEntry(t *testing.T) {
+	n := NewNameEntry("")
+	if n.Name() != "AAA" {
+		t.Errorf("Expected AAA to start, got %q", n.Name())
+	}
+
+	n.Down() // wraps round to the end of the alphabet
+	n.Right()
+	n.Up()
+	n.Right()
+	n.Right() // stays on the last letter
+	n.Up()
+	n.Up()
+	if n.Name() != " BC" || n.Cursor() != NameLength-1 {
+		t.Errorf("Expected \" BC\" with the cursor at the end, got %q at %d", n.Name(), n.Cursor())
+	}
+
+	if got := NewNameEntry("Z9!").Name(); got != "Z9A" {
+		t.Errorf("Expected the last name to carry over, got %q", got)
+	}
+}
@@ -0,0 +1,63 @@
+package highscore
+
+// NameLength is the number of characters in a table name
+const NameLength = 3
+
+// nameAlphabet is what each character of a name cycles through
+const nameAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 "
+
+// NameEntry edits a name arcade style: up and down cycle the character
+// under the cursor, left and right move the cursor
+type NameEntry struct {
+	letters [NameLength]int
+	cursor  int
+}
+
+// NewNameEntry starts editing from name, such as the last one entered.
+// Characters outside the alphabet start as A.
+func NewNameEntry(name string) *NameEntry {
+	n := &NameEntry{}
+	for i := 0; i < NameLength && i < len(name); i++ {
+		for j := 0; j < len(nameAlphabet); j++ {
+			if nameAlphabet[j] == name[i] {
+				n.letters[i] = j
+				break
+			}
+		}
+	}
+	return n
+}
+
+// Up moves the character under the cursor forward through the alphabet
+func (n *NameEntry) Up() {
+	n.letters[n.cursor] = (n.letters[n.cursor] + 1) % len(nameAlphabet)
+}
+
+// Down moves the character under the cursor back through the alphabet
+func (n *NameEntry) Down() {
+	n.letters[n.cursor] = (n.letters[n.cursor] + len(nameAlphabet) - 1) % len(nameAlphabet)
+}
+
+// Left moves the cursor to the previous character
+func (n *NameEntry) Left() {
+	n.cursor = max(0, n.cursor-1)
+}
+
+// Right moves the cursor to the next character
+func (n *NameEntry) Right() {
+	n.cursor = min(NameLength-1, n.cursor+1)
+}
+
+// Cursor returns the index of the character being edited
+func (n *NameEntry) Cursor() int {
+	return n.cursor
+}
+
+// Name returns the name as entered so far
+func (n *NameEntry) Name() string {
+	name := make([]byte, NameLength)
+	for i, l := range n.letters {
+		name[i] = nameAlphabet[l]
+	}
+	return string(name)
+}
@@ -0,0 +1,66 @@
+package input
+
+// Action is a bit set of the controls held during a single tick
+type Action uint32
+
+const (
+	ActionUp Action = 1 << iota
+	ActionDown
+	ActionLeft
+	ActionRight
+	ActionFire
+	ActionPause
+	ActionConfirm
+	ActionBack
+	ActionScores
+)
+
+// None is the empty action set
+const None Action = 0
+
+// Has reports whether any of the given actions are set
+func (a Action) Has(actions Action) bool {
+	return a&actions != 0
+}
+
+// Source produces the player's actions tick by tick
+type Source interface {
+	// Poll returns the actions held during the current tick.
+	// The game calls it exactly once per tick.
+	Poll() Action
+}
+
+// Script is a Source that plays back a fixed list of per-tick actions.
+// Once the list is exhausted it reports no input.
+type Script struct {
+	ticks []Action
+	pos   int
+}
+
+// NewScript creates a script from per-tick actions
+func NewScript(ticks ...Action) *Script {
+	return &Script{ticks: ticks}
+}
+
+// Poll returns the next scripted action
+func (s *Script) Poll() Action {
+	if s.pos >= len(s.ticks) {
+		return None
+	}
+	a := s.ticks[s.pos]
+	s.pos++
+	return a
+}
+
+// Done reports whether every scripted tick has been played
+func (s *Script) Done() bool {
+	return s.pos >= len(s.ticks)
+}
+
+// Hold appends an action held for the given number of ticks
+func (s *Script) Hold(a Action, ticks int) *Script {
+	for i := 0; i < ticks; i++ {
+		s.ticks = append(s.ticks, a)
+	}
+	return s
+}
@@ -0,0 +1,44 @@
+package input
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Keyboard is a Source that reads the keyboard through ebiten
+type Keyboard struct{}
+
+// NewKeyboard creates a keyboard input source
+func NewKeyboard() *Keyboard {
+	return &Keyboard{}
+}
+
+// Poll returns the actions for the keys currently held
+func (k *Keyboard) Poll() Action {
+	var a Action
+	if ebiten.IsKeyPressed(ebiten.KeyW) || ebiten.IsKeyPressed(ebiten.KeyArrowUp) {
+		a |= ActionUp
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyS) || ebiten.IsKeyPressed(ebiten.KeyArrowDown) {
+		a |= ActionDown
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyA) || ebiten.IsKeyPressed(ebiten.KeyArrowLeft) {
+		a |= ActionLeft
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyD) || ebiten.IsKeyPressed(ebiten.KeyArrowRight) {
+		a |= ActionRight
+	}
+	if ebiten.IsKeyPressed(ebiten.KeySpace) {
+		a |= ActionFire
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyP) {
+		a |= ActionPause
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyEnter) {
+		a |= ActionConfirm
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyEscape) {
+		a |= ActionBack
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyH) {
+		a |= ActionScores
+	}
+	return a
+}
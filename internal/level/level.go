@@ -0,0 +1,211 @@
+// Package level loads scripted levels: timed waves of enemies with entry
+// points, formations and movement, separated by breaks.
+package level
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Entry edges
+const (
+	EntryTop   = "top"
+	EntryLeft  = "left"
+	EntryRight = "right"
+)
+
+// Formation shapes. Groups without one enter one by one.
+const (
+	FormationNone   = ""
+	FormationLine   = "line"
+	FormationColumn = "column"
+	FormationV      = "v"
+	FormationGrid   = "grid"
+)
+
+// Wave clear conditions
+const (
+	ClearDestroyed = "destroyed"
+	ClearTimer     = "timer"
+)
+
+// Level is a sequence of waves. Break is the pause before each wave
+// unless the wave sets its own. Theme picks the background, or the
+// config's when empty.
+type Level struct {
+	Name  string  `yaml:"name"`
+	Theme string  `yaml:"theme"`
+	Break float64 `yaml:"break"`
+	Waves []Wave  `yaml:"waves"`
+}
+
+// Wave is a set of enemy groups that must be cleared before the next wave.
+// A destroyed wave ends once every enemy has spawned and none are left,
+// or after Duration seconds if it is set; a timer wave ends after Duration.
+type Wave struct {
+	Groups   []Group  `yaml:"enemies"`
+	Clear    string   `yaml:"clear"`
+	Duration float64  `yaml:"duration"`
+	Break    *float64 `yaml:"break"`
+}
+
+// Group is Count enemies of one type entering together.
+// Position is where along the entry edge they appear, from 0 to 1.
+// Without a formation they enter Interval seconds apart, Spacing pixels
+// apart along the edge; in a formation they keep station Spacing pixels
+// apart on an anchor drifting in at Speed, swaying Sway pixels.
+// A group naming a Boss from the config brings in that boss instead,
+// once any boss already in play is destroyed.
+type Group struct {
+	Type      string  `yaml:"type"`
+	Boss      string  `yaml:"boss"`
+	Count     int     `yaml:"count"`
+	Entry     string  `yaml:"entry"`
+	Position  float64 `yaml:"position"`
+	Spacing   float64 `yaml:"spacing"`
+	Interval  float64 `yaml:"interval"`
+	Delay     float64 `yaml:"delay"`
+	Movement  string  `yaml:"movement"`
+	Formation string  `yaml:"formation"`
+	Speed     float64 `yaml:"speed"`
+	Sway      float64 `yaml:"sway"`
+}
+
+func defaultGroup() Group {
+	return Group{
+		Count:    1,
+		Entry:    EntryTop,
+		Position: 0.5,
+		Spacing:  50,
+		Speed:    60,
+	}
+}
+
+// UnmarshalYAML starts each group from the defaults
+func (g *Group) UnmarshalYAML(node *yaml.Node) error {
+	type plain Group
+	*g = defaultGroup()
+	return node.Decode((*plain)(g))
+}
+
+// Load reads and validates a level file
+func Load(path string) (*Level, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading level: %w", err)
+	}
+
+	l, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return l, nil
+}
+
+// Parse decodes and validates YAML level data
+func Parse(data []byte) (*Level, error) {
+	l := &Level{Break: 3}
+	if err := yaml.Unmarshal(data, l); err != nil {
+		return nil, fmt.Errorf("parsing level: %w", err)
+	}
+
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Validate checks that the level can be played
+func (l *Level) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(l.Theme == "" || contains(config.ThemeNames(), l.Theme), "theme %q is not one of %s", l.Theme, strings.Join(config.ThemeNames(), ", "))
+	check(l.Break >= 0, "break must not be negative, got %g", l.Break)
+	check(len(l.Waves) > 0, "waves must list at least one wave")
+
+	for i, w := range l.Waves {
+		field := fmt.Sprintf("waves[%d]", i)
+		check(len(w.Groups) > 0, "%s.enemies must list at least one group", field)
+		check(w.Clear == "" || w.Clear == ClearDestroyed || w.Clear == ClearTimer,
+			"%s.clear %q is not one of %s, %s", field, w.Clear, ClearDestroyed, ClearTimer)
+		check(w.Duration >= 0, "%s.duration must not be negative, got %g", field, w.Duration)
+		check(w.Clear != ClearTimer || w.Duration > 0, "%s.duration must be positive for a timer wave", field)
+		check(w.Break == nil || *w.Break >= 0, "%s.break must not be negative", field)
+
+		for j, g := range w.Groups {
+			field := fmt.Sprintf("%s.enemies[%d]", field, j)
+			if g.Boss != "" {
+				check(g.Type == "", "%s cannot set both type and boss", field)
+				check(g.Count == 1, "%s.count must be 1 for a boss, got %d", field, g.Count)
+				check(g.Formation == FormationNone && g.Movement == config.MoveDefault,
+					"%s.formation and movement cannot be set for a boss", field)
+			} else {
+				check(contains(config.EnemyTypeNames(), g.Type), "%s.type %q is not one of %s", field, g.Type, strings.Join(config.EnemyTypeNames(), ", "))
+			}
+			check(g.Count > 0, "%s.count must be positive, got %d", field, g.Count)
+			check(contains(EntryNames(), g.Entry), "%s.entry %q is not one of %s", field, g.Entry, strings.Join(EntryNames(), ", "))
+			check(g.Position >= 0 && g.Position <= 1, "%s.position must be between 0 and 1, got %g", field, g.Position)
+			check(g.Spacing >= 0, "%s.spacing must not be negative, got %g", field, g.Spacing)
+			check(g.Interval >= 0, "%s.interval must not be negative, got %g", field, g.Interval)
+			check(g.Delay >= 0, "%s.delay must not be negative, got %g", field, g.Delay)
+			check(g.Movement == config.MoveDefault || contains(config.MovementNames(), g.Movement),
+				"%s.movement %q is not one of %s", field, g.Movement, strings.Join(config.MovementNames(), ", "))
+			check(g.Formation == FormationNone || contains(FormationNames(), g.Formation),
+				"%s.formation %q is not one of %s", field, g.Formation, strings.Join(FormationNames(), ", "))
+			check(g.Formation == FormationNone || g.Movement == config.MoveDefault,
+				"%s.movement cannot be set for a group in formation", field)
+			check(g.Speed > 0, "%s.speed must be positive, got %g", field, g.Speed)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid level: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// CheckBosses reports any boss the level uses that cfg does not define
+func (l *Level) CheckBosses(cfg *config.Config) error {
+	var errs []error
+	for i, w := range l.Waves {
+		for j, g := range w.Groups {
+			if _, ok := cfg.Boss(g.Boss); g.Boss != "" && !ok {
+				errs = append(errs, fmt.Errorf("waves[%d].enemies[%d].boss %q is not defined in the config", i, j, g.Boss))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid level: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// EntryNames returns the recognised entry edges
+func EntryNames() []string {
+	return []string{EntryTop, EntryLeft, EntryRight}
+}
+
+// FormationNames returns the recognised formation shapes
+func FormationNames() []string {
+	return []string{FormationLine, FormationColumn, FormationV, FormationGrid}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
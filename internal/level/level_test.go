@@ -0,0 +1,99 @@
+package level
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+)
+
+func TestLoadLevel1(t *testing.T) {
+	l, err := Load("../../configs/levels/level1.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load level1.yaml: %v", err)
+	}
+
+	if len(l.Waves) != 6 {
+		t.Errorf("Expected 6 waves, got %d", len(l.Waves))
+	}
+	if l.Theme != config.ThemeNebula {
+		t.Errorf("Expected the nebula theme, got %q", l.Theme)
+	}
+	if l.Waves[4].Clear != ClearTimer {
+		t.Errorf("Expected the fifth wave to be timed, got %q", l.Waves[4].Clear)
+	}
+	if err := l.CheckBosses(config.Default()); err != nil {
+		t.Errorf("Expected level1's bosses to be defined: %v", err)
+	}
+}
+
+func TestParseGroupDefaults(t *testing.T) {
+	l, err := Parse([]byte(`
+waves:
+  - enemies:
+      - type: "basic"
+`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	g := l.Waves[0].Groups[0]
+	if g.Count != 1 || g.Entry != EntryTop || g.Position != 0.5 {
+		t.Errorf("Expected group defaults, got %+v", g)
+	}
+	if l.Break != 3 {
+		t.Errorf("Expected default break 3, got %g", l.Break)
+	}
+}
+
+func TestParseRejectsBadLevels(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"no waves", "name: empty\n", "waves must list"},
+		{"unknown theme", "theme: \"void\"\nwaves:\n  - enemies:\n      - type: \"basic\"\n", "theme"},
+		{"unknown type", "waves:\n  - enemies:\n      - type: \"ufo\"\n", "waves[0].enemies[0].type"},
+		{"bad entry", "waves:\n  - enemies:\n      - type: \"basic\"\n        entry: \"below\"\n", "waves[0].enemies[0].entry"},
+		{"bad formation", "waves:\n  - enemies:\n      - type: \"basic\"\n        formation: \"circle\"\n", "waves[0].enemies[0].formation"},
+		{"timer without duration", "waves:\n  - clear: \"timer\"\n    enemies:\n      - type: \"basic\"\n", "waves[0].duration"},
+		{"boss with type", "waves:\n  - enemies:\n      - type: \"basic\"\n        boss: \"dreadnought\"\n", "cannot set both type and boss"},
+		{"boss count", "waves:\n  - enemies:\n      - boss: \"dreadnought\"\n        count: 2\n", "waves[0].enemies[0].count"},
+		{"movement in formation", "waves:\n  - enemies:\n      - type: \"basic\"\n        formation: \"v\"\n        movement: \"seek\"\n", "waves[0].enemies[0].movement"},
+		{"malformed", "waves: [", "parsing level"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.yaml))
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error mentioning %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestCheckBosses(t *testing.T) {
+	l, err := Parse([]byte(`
+waves:
+  - enemies:
+      - boss: "dreadnought"
+  - enemies:
+      - boss: "mothership"
+`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	err = l.CheckBosses(config.Default())
+	if err == nil {
+		t.Fatal("Expected an undefined boss to be reported")
+	}
+	if !strings.Contains(err.Error(), "waves[1].enemies[0].boss") || strings.Contains(err.Error(), "waves[0]") {
+		t.Errorf("Expected only the mothership to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,231 @@
+package level
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/EchoSingh/space-shooter/internal/entities"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+// Spawn asks the game to put one enemy, or the named Boss, into play.
+// Enemies in a formation share its Formation and keep station at Offset
+// from its anchor.
+type Spawn struct {
+	Type      string
+	Boss      string
+	Position  vector.Vector2
+	Movement  string
+	Formation *entities.Formation
+	Offset    vector.Vector2
+
+	at float64
+}
+
+type phase int
+
+const (
+	phaseBreak phase = iota
+	phaseWave
+	phaseComplete
+	phaseDone
+)
+
+// Runner plays a level's waves in order
+type Runner struct {
+	level  *Level
+	width  float64
+	height float64
+
+	wave  int
+	phase phase
+	timer float64
+	queue []Spawn
+	out   []Spawn
+}
+
+// NewRunner creates a runner for a level on a screen of the given size.
+// It starts with the break before the first wave.
+func NewRunner(l *Level, width, height float64) *Runner {
+	return &Runner{
+		level:  l,
+		width:  width,
+		height: height,
+	}
+}
+
+// Update advances the level by dt. now is the game time, used to start
+// formations, and alive is the number of enemies still in play.
+// It returns the enemies to spawn this tick; the slice is reused.
+func (r *Runner) Update(dt, now float64, alive int) []Spawn {
+	r.out = r.out[:0]
+	r.timer += dt
+
+	switch r.phase {
+	case phaseBreak:
+		if r.timer >= r.breakBefore(r.wave) {
+			r.startWave(now)
+			r.emitDue()
+		}
+	case phaseWave:
+		// Clearing is judged on last tick's spawns, which alive now counts
+		if r.cleared(alive) {
+			r.wave++
+			r.timer = 0
+			r.phase = phaseBreak
+			if r.wave == len(r.level.Waves) {
+				r.phase = phaseComplete
+			}
+			return r.out
+		}
+		r.emitDue()
+	case phaseComplete:
+		if r.timer >= r.level.Break {
+			r.phase = phaseDone
+		}
+	}
+	return r.out
+}
+
+// Done reports whether every wave has been cleared
+func (r *Runner) Done() bool {
+	return r.phase == phaseDone
+}
+
+// Cleared returns the number of waves cleared so far
+func (r *Runner) Cleared() int {
+	return r.wave
+}
+
+// Wave returns the 1-based number of the current or upcoming wave
+func (r *Runner) Wave() int {
+	return min(r.wave+1, len(r.level.Waves))
+}
+
+// Waves returns the number of waves in the level
+func (r *Runner) Waves() int {
+	return len(r.level.Waves)
+}
+
+// Banner returns the announcement to show, if any: the wave number
+// during the break before it and a completion message at the end
+func (r *Runner) Banner() (string, bool) {
+	switch r.phase {
+	case phaseBreak:
+		return fmt.Sprintf("WAVE %d", r.Wave()), true
+	case phaseComplete:
+		return "LEVEL COMPLETE", true
+	}
+	return "", false
+}
+
+func (r *Runner) breakBefore(wave int) float64 {
+	if b := r.level.Waves[wave].Break; b != nil {
+		return *b
+	}
+	return r.level.Break
+}
+
+func (r *Runner) cleared(alive int) bool {
+	w := r.level.Waves[r.wave]
+	timedOut := w.Duration > 0 && r.timer >= w.Duration
+	if w.Clear == ClearTimer {
+		return timedOut
+	}
+	return timedOut || len(r.queue) == 0 && alive == 0
+}
+
+// emitDue moves spawns that are due from the queue to the output
+func (r *Runner) emitDue() {
+	n := 0
+	for n < len(r.queue) && r.queue[n].at <= r.timer {
+		n++
+	}
+	r.out = append(r.out, r.queue[:n]...)
+	r.queue = r.queue[n:]
+}
+
+// startWave schedules every enemy in the next wave
+func (r *Runner) startWave(now float64) {
+	r.phase = phaseWave
+	r.timer = 0
+	r.queue = r.queue[:0]
+
+	for _, g := range r.level.Waves[r.wave].Groups {
+		r.queue = r.schedule(r.queue, g, now)
+	}
+	sort.SliceStable(r.queue, func(i, j int) bool {
+		return r.queue[i].at < r.queue[j].at
+	})
+}
+
+// schedule appends a group's spawns
+func (r *Runner) schedule(queue []Spawn, g Group, now float64) []Spawn {
+	origin, forward, along := r.entry(g)
+
+	if g.Formation == FormationNone {
+		for i := 0; i < g.Count; i++ {
+			offset := (float64(i) - float64(g.Count-1)/2) * g.Spacing
+			queue = append(queue, Spawn{
+				Type:     g.Type,
+				Boss:     g.Boss,
+				Position: origin.Add(along.Mul(offset)),
+				Movement: g.Movement,
+				at:       g.Delay + float64(i)*g.Interval,
+			})
+		}
+		return queue
+	}
+
+	formation := &entities.Formation{
+		Origin:   origin,
+		Velocity: forward.Mul(g.Speed),
+		Sway:     g.Sway,
+		Start:    now + g.Delay,
+	}
+	// Slots are laid out facing down, then turned to face the entry direction
+	turn := forward.Angle() - math.Pi/2
+	for i := 0; i < g.Count; i++ {
+		offset := formationSlot(g.Formation, i, g.Count, g.Spacing).Rotate(turn)
+		queue = append(queue, Spawn{
+			Type:      g.Type,
+			Position:  origin.Add(offset),
+			Movement:  g.Movement,
+			Formation: formation,
+			Offset:    offset,
+			at:        g.Delay,
+		})
+	}
+	return queue
+}
+
+// entry returns where a group enters, the direction it travels in and the
+// direction along the entry edge
+func (r *Runner) entry(g Group) (origin, forward, along vector.Vector2) {
+	switch g.Entry {
+	case EntryLeft:
+		return vector.New(-30, g.Position*r.height), vector.New(1, 0), vector.New(0, 1)
+	case EntryRight:
+		return vector.New(r.width+30, g.Position*r.height), vector.New(-1, 0), vector.New(0, 1)
+	}
+	return vector.New(g.Position*r.width, -30), vector.New(0, 1), vector.New(1, 0)
+}
+
+// formationSlot returns member i's offset in a formation facing down,
+// with later ranks further back
+func formationSlot(shape string, i, count int, spacing float64) vector.Vector2 {
+	centre := float64(count-1) / 2
+	switch shape {
+	case FormationColumn:
+		return vector.New(0, -float64(i)*spacing)
+	case FormationV:
+		x := float64(i) - centre
+		return vector.New(x*spacing, -math.Abs(x)*spacing*0.6)
+	case FormationGrid:
+		cols := int(math.Ceil(math.Sqrt(float64(count))))
+		row, col := i/cols, i%cols
+		return vector.New((float64(col)-float64(cols-1)/2)*spacing, -float64(row)*spacing)
+	}
+	return vector.New((float64(i)-centre)*spacing, 0)
+}
@@ -0,0 +1,152 @@
+package level
+
+import (
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+const dt = 1.0 / 60
+
+func mustParse(t *testing.T, data string) *Level {
+	t.Helper()
+	l, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return l
+}
+
+// run advances r for the given seconds with no enemies alive and
+// returns everything it spawned
+func run(r *Runner, seconds float64) []Spawn {
+	var spawned []Spawn
+	for i := 0; i < int(seconds/dt+0.5); i++ {
+		spawned = append(spawned, r.Update(dt, 0, 0)...)
+	}
+	return spawned
+}
+
+func TestRunnerBreakThenStaggeredSpawns(t *testing.T) {
+	r := NewRunner(mustParse(t, `
+break: 1
+waves:
+  - enemies:
+      - type: "basic"
+        count: 3
+        interval: 0.5
+        spacing: 100
+`), 800, 600)
+
+	if banner, ok := r.Banner(); !ok || banner != "WAVE 1" {
+		t.Errorf("Expected a WAVE 1 banner during the break, got %q", banner)
+	}
+	if spawned := run(r, 0.9); len(spawned) != 0 {
+		t.Fatalf("Expected nothing during the break, got %d", len(spawned))
+	}
+
+	spawned := run(r, 0.2)
+	if len(spawned) != 1 {
+		t.Fatalf("Expected the first enemy after the break, got %d", len(spawned))
+	}
+	if want := vector.New(300, -30); spawned[0].Position != want {
+		t.Errorf("Expected first enemy at %v, got %v", want, spawned[0].Position)
+	}
+
+	spawned = run(r, 1.0)
+	if len(spawned) != 2 {
+		t.Errorf("Expected the other two enemies within a second, got %d", len(spawned))
+	}
+}
+
+func TestRunnerFormation(t *testing.T) {
+	r := NewRunner(mustParse(t, `
+break: 0
+waves:
+  - enemies:
+      - type: "fast"
+        count: 4
+        entry: "left"
+        position: 0.5
+        formation: "line"
+        spacing: 40
+        speed: 100
+`), 800, 600)
+
+	spawned := r.Update(dt, 5, 0)
+	if len(spawned) != 4 {
+		t.Fatalf("Expected the whole formation at once, got %d", len(spawned))
+	}
+
+	f := spawned[0].Formation
+	if f == nil || spawned[3].Formation != f {
+		t.Fatal("Expected every member to share one formation")
+	}
+	if f.Velocity != vector.New(100, 0) || f.Start != 5 {
+		t.Errorf("Expected formation heading right from time 5, got %+v", f)
+	}
+	for _, s := range spawned {
+		if s.Position != f.Origin.Add(s.Offset) {
+			t.Errorf("Expected member to start in its slot, got %v for offset %v", s.Position, s.Offset)
+		}
+	}
+}
+
+func TestRunnerWaitsForWaveClear(t *testing.T) {
+	r := NewRunner(mustParse(t, `
+break: 0
+waves:
+  - enemies:
+      - type: "basic"
+  - enemies:
+      - type: "tank"
+`), 800, 600)
+
+	if spawned := r.Update(dt, 0, 0); len(spawned) != 1 || spawned[0].Type != "basic" {
+		t.Fatalf("Expected the first wave's basic enemy, got %+v", spawned)
+	}
+	for i := 0; i < 100; i++ {
+		if spawned := r.Update(dt, 0, 1); len(spawned) != 0 {
+			t.Fatal("Next wave should wait while an enemy is alive")
+		}
+	}
+
+	r.Update(dt, 0, 0)
+	spawned := r.Update(dt, 0, 0)
+	if len(spawned) != 1 || spawned[0].Type != "tank" {
+		t.Errorf("Expected the second wave once clear, got %+v", spawned)
+	}
+	if r.Wave() != 2 || r.Cleared() != 1 {
+		t.Errorf("Expected wave 2 with one cleared, got %d with %d", r.Wave(), r.Cleared())
+	}
+}
+
+func TestRunnerTimerWaveAndCompletion(t *testing.T) {
+	r := NewRunner(mustParse(t, `
+break: 0.5
+waves:
+  - clear: "timer"
+    duration: 2
+    enemies:
+      - type: "basic"
+`), 800, 600)
+
+	for i := 0; i < 120; i++ {
+		r.Update(dt, 0, 5)
+	}
+	if banner, _ := r.Banner(); banner == "LEVEL COMPLETE" {
+		t.Fatal("Timer wave should not end before its duration")
+	}
+
+	for i := 0; i < 60; i++ {
+		r.Update(dt, 0, 5)
+	}
+	if banner, ok := r.Banner(); !ok || banner != "LEVEL COMPLETE" {
+		t.Errorf("Expected the level to complete, got %q", banner)
+	}
+
+	run(r, 1)
+	if !r.Done() {
+		t.Error("Expected the runner to finish after the final break")
+	}
+}
@@ -2,18 +2,58 @@ package physics
 
 import (
 	"github.com/EchoSingh/space-shooter/internal/entities"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
 )
 
+// span locates a collider's vertices in the shared vertex buffer
+type span struct {
+	start, end int
+}
+
 // CollisionSystem handles collision detection
 type CollisionSystem struct {
 	entities []entities.Entity
+	layers   *LayerTable
+	grid     *SpatialHash
+	pairs    []CollisionPair
+
+	// Per-frame world-space shapes, indexed like entities
+	colliders []collider
+	spans     []span
+	verts     []vector.Vector2
+	scratch   []vector.Vector2
+}
+
+// Option configures a CollisionSystem
+type Option func(*CollisionSystem)
+
+// WithCellSize sets the broad phase grid cell size
+func WithCellSize(cellSize float64) Option {
+	return func(cs *CollisionSystem) {
+		cs.grid = NewSpatialHash(cellSize)
+	}
 }
 
-// NewCollisionSystem creates a new collision system
-func NewCollisionSystem() *CollisionSystem {
-	return &CollisionSystem{
+// WithLayers sets the layer table deciding which entities collide
+func WithLayers(table *LayerTable) Option {
+	return func(cs *CollisionSystem) {
+		cs.layers = table
+	}
+}
+
+// NewCollisionSystem creates a new collision system.
+// Without options it uses DefaultLayerTable and DefaultCellSize.
+func NewCollisionSystem(opts ...Option) *CollisionSystem {
+	cs := &CollisionSystem{
 		entities: make([]entities.Entity, 0, 100),
+		layers:   DefaultLayerTable(),
+		grid:     NewSpatialHash(DefaultCellSize),
+		pairs:    make([]CollisionPair, 0, 64),
 	}
+	for _, opt := range opts {
+		opt(cs)
+	}
+	return cs
 }
 
 // AddEntity adds an entity to collision detection
@@ -26,9 +66,53 @@ func (cs *CollisionSystem) Clear() {
 	cs.entities = cs.entities[:0]
 }
 
-// CheckCollisions checks all collisions
+// CheckCollisions checks all collisions using the spatial hash broad phase.
+// The returned slice is reused by the next call.
 func (cs *CollisionSystem) CheckCollisions() []CollisionPair {
-	collisions := make([]CollisionPair, 0)
+	cs.pairs = cs.pairs[:0]
+	cs.prepare()
+
+	cs.grid.Clear()
+	for i, e := range cs.entities {
+		if e.IsActive() {
+			cs.grid.Insert(i, cs.colliders[i].bounds())
+		}
+	}
+
+	cs.grid.Pairs(func(i, j int) {
+		if cs.checkCollision(i, j) {
+			cs.pairs = append(cs.pairs, CollisionPair{A: cs.entities[i], B: cs.entities[j]})
+		}
+	})
+
+	return cs.pairs
+}
+
+// QuerySegment appends to hits every entity touched by a segment from a
+// to b with the given thickness radius, filtered as if the segment were
+// on layer. It tests the shapes placed by the last CheckCollisions call.
+func (cs *CollisionSystem) QuerySegment(a, b vector.Vector2, radius float64, layer Layer, hits []entities.Entity) []entities.Entity {
+	if len(cs.colliders) != len(cs.entities) {
+		cs.prepare()
+	}
+
+	q := collider{center: b, prev: a, radius: radius, swept: a != b}
+	for i, e := range cs.entities {
+		if !e.IsActive() || !cs.layers.Collides(layer, LayerOf(e)) {
+			continue
+		}
+		if q.overlaps(&cs.colliders[i]) {
+			hits = append(hits, e)
+		}
+	}
+	return hits
+}
+
+// checkBruteForce tests every pair of entities.
+// It is kept as a reference for the broad phase.
+func (cs *CollisionSystem) checkBruteForce() []CollisionPair {
+	cs.pairs = cs.pairs[:0]
+	cs.prepare()
 
 	for i := 0; i < len(cs.entities); i++ {
 		if !cs.entities[i].IsActive() {
@@ -40,8 +124,8 @@ func (cs *CollisionSystem) CheckCollisions() []CollisionPair {
 				continue
 			}
 
-			if cs.checkCollision(cs.entities[i], cs.entities[j]) {
-				collisions = append(collisions, CollisionPair{
+			if cs.checkCollision(i, j) {
+				cs.pairs = append(cs.pairs, CollisionPair{
 					A: cs.entities[i],
 					B: cs.entities[j],
 				})
@@ -49,52 +133,81 @@ func (cs *CollisionSystem) CheckCollisions() []CollisionPair {
 		}
 	}
 
-	return collisions
+	return cs.pairs
 }
 
-// checkCollision checks if two entities collide (circle collision)
-func (cs *CollisionSystem) checkCollision(a, b entities.Entity) bool {
-	// Skip collision between same types in some cases
-	if !cs.shouldCollide(a, b) {
-		return false
-	}
-
-	// Circle-circle collision
-	distance := a.GetPosition().DistanceSquared(b.GetPosition())
-	radiusSum := a.GetRadius() + b.GetRadius()
+// prepare places every entity's shape in the world for this frame
+func (cs *CollisionSystem) prepare() {
+	cs.colliders = cs.colliders[:0]
+	cs.spans = cs.spans[:0]
+	cs.verts = cs.verts[:0]
 
-	return distance <= radiusSum*radiusSum
-}
+	for _, e := range cs.entities {
+		c := cs.colliderOf(e)
+		sp := span{start: len(cs.verts)}
+		cs.verts = append(cs.verts, c.verts...)
+		sp.end = len(cs.verts)
 
-// shouldCollide determines if two entities should collide
-func (cs *CollisionSystem) shouldCollide(a, b entities.Entity) bool {
-	typeA := a.GetType()
-	typeB := b.GetType()
+		cs.colliders = append(cs.colliders, c)
+		cs.spans = append(cs.spans, sp)
+	}
 
-	// Player bullets don't collide with player
-	if typeA == entities.TypePlayer && typeB == entities.TypeBullet {
-		return false
+	// Point polygons at the shared buffer now that it has stopped growing
+	for i, sp := range cs.spans {
+		if cs.colliders[i].verts != nil {
+			cs.colliders[i].verts = cs.verts[sp.start:sp.end]
+		}
 	}
-	if typeA == entities.TypeBullet && typeB == entities.TypePlayer {
-		return false
+}
+
+// colliderOf resolves an entity's shape, preferring Shaped, then a
+// hitbox, then its radius. Fast moving circles are swept.
+func (cs *CollisionSystem) colliderOf(e entities.Entity) collider {
+	pos := e.GetPosition()
+
+	var c collider
+	switch {
+	case isShaped(e):
+		c = makeCollider(e.(Shaped).CollisionShape(), pos, cs.scratch[:0])
+	case hitboxOf(e) != nil:
+		c = collider{center: pos, prev: pos, verts: appendHitbox(cs.scratch[:0], pos, hitboxOf(e))}
+	default:
+		c = collider{center: pos, prev: pos, radius: e.GetRadius()}
 	}
 
-	// Bullets don't collide with each other
-	if typeA == entities.TypeBullet && typeB == entities.TypeBullet {
-		return false
+	if c.verts != nil {
+		cs.scratch = c.verts[:0]
+	} else if fm, ok := e.(FastMover); ok && fm.IsFastMover() {
+		// Fast circles are swept along their path since the last tick
+		c.prev = fm.GetPreviousPosition()
+		c.swept = c.prev != c.center
 	}
+	return c
+}
 
-	// Particles don't collide
-	if typeA == entities.TypeParticle || typeB == entities.TypeParticle {
-		return false
+func isShaped(e entities.Entity) bool {
+	_, ok := e.(Shaped)
+	return ok
+}
+
+func hitboxOf(e entities.Entity) *entities.Hitbox {
+	if h, ok := e.(hitboxed); ok {
+		return h.GetHitbox()
 	}
+	return nil
+}
 
-	// Enemies don't collide with each other
-	if typeA == entities.TypeEnemy && typeB == entities.TypeEnemy {
+// checkCollision checks if entities i and j collide
+func (cs *CollisionSystem) checkCollision(i, j int) bool {
+	if !cs.shouldCollide(cs.entities[i], cs.entities[j]) {
 		return false
 	}
+	return cs.colliders[i].overlaps(&cs.colliders[j])
+}
 
-	return true
+// shouldCollide determines if two entities should collide
+func (cs *CollisionSystem) shouldCollide(a, b entities.Entity) bool {
+	return cs.layers.Collides(LayerOf(a), LayerOf(b))
 }
 
 // CollisionPair represents a collision between two entities
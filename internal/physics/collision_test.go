@@ -1,12 +1,20 @@
 package physics
 
 import (
+	"fmt"
+	"math/rand"
 	"testing"
 
+	"github.com/EchoSingh/space-shooter/internal/config"
 	"github.com/EchoSingh/space-shooter/internal/entities"
 	"github.com/EchoSingh/space-shooter/pkg/vector"
 )
 
+var (
+	testPlayerConfig  = config.Default().Player
+	testBasicEnemy, _ = config.Default().Enemies.EnemyType(config.EnemyBasic)
+)
+
 func TestNewCollisionSystem(t *testing.T) {
 	cs := NewCollisionSystem()
 
@@ -20,7 +28,7 @@ func TestAddEntity(t *testing.T) {
 		t.Skip("Skipping test in short mode (CI environment)")
 	}
 	cs := NewCollisionSystem()
-	player := entities.NewPlayer(100, 100, 800, 600)
+	player := entities.NewPlayer(100, 100, 800, 600, testPlayerConfig)
 
 	cs.AddEntity(player)
 
@@ -34,7 +42,7 @@ func TestClear(t *testing.T) {
 		t.Skip("Skipping test in short mode (CI environment)")
 	}
 	cs := NewCollisionSystem()
-	player := entities.NewPlayer(100, 100, 800, 600)
+	player := entities.NewPlayer(100, 100, 800, 600, testPlayerConfig)
 
 	cs.AddEntity(player)
 	cs.Clear()
@@ -51,8 +59,8 @@ func TestCollisionDetection(t *testing.T) {
 	cs := NewCollisionSystem()
 
 	// Create two entities that should collide
-	player := entities.NewPlayer(100, 100, 800, 600)
-	enemy := entities.NewEnemy(entities.EnemyBasic, 105, 105, 800, 600)
+	player := entities.NewPlayer(100, 100, 800, 600, testPlayerConfig)
+	enemy := entities.NewEnemy(entities.EnemyBasic, 105, 105, 800, 600, testBasicEnemy)
 
 	cs.AddEntity(player)
 	cs.AddEntity(enemy)
@@ -70,8 +78,8 @@ func TestNoCollisionWhenFarApart(t *testing.T) {
 	}
 	cs := NewCollisionSystem()
 
-	player := entities.NewPlayer(100, 100, 800, 600)
-	enemy := entities.NewEnemy(entities.EnemyBasic, 500, 500, 800, 600)
+	player := entities.NewPlayer(100, 100, 800, 600, testPlayerConfig)
+	enemy := entities.NewEnemy(entities.EnemyBasic, 500, 500, 800, 600, testBasicEnemy)
 
 	cs.AddEntity(player)
 	cs.AddEntity(enemy)
@@ -89,7 +97,7 @@ func TestBulletPlayerNoCollision(t *testing.T) {
 	}
 	cs := NewCollisionSystem()
 
-	player := entities.NewPlayer(100, 100, 800, 600)
+	player := entities.NewPlayer(100, 100, 800, 600, testPlayerConfig)
 	bullet := entities.NewBullet(100, 100, vector.Zero(), 10, entities.OwnerPlayer, 800, 600)
 
 	cs.AddEntity(player)
@@ -108,8 +116,8 @@ func TestInactiveEntityNoCollision(t *testing.T) {
 	}
 	cs := NewCollisionSystem()
 
-	player := entities.NewPlayer(100, 100, 800, 600)
-	enemy := entities.NewEnemy(entities.EnemyBasic, 105, 105, 800, 600)
+	player := entities.NewPlayer(100, 100, 800, 600, testPlayerConfig)
+	enemy := entities.NewEnemy(entities.EnemyBasic, 105, 105, 800, 600, testBasicEnemy)
 	enemy.SetActive(false)
 
 	cs.AddEntity(player)
@@ -130,7 +138,7 @@ func BenchmarkCollisionDetection(b *testing.B) {
 
 	// Add many entities
 	for i := 0; i < 50; i++ {
-		enemy := entities.NewEnemy(entities.EnemyBasic, float64(i*10), float64(i*10), 800, 600)
+		enemy := entities.NewEnemy(entities.EnemyBasic, float64(i*10), float64(i*10), 800, 600, testBasicEnemy)
 		cs.AddEntity(enemy)
 	}
 
@@ -139,3 +147,230 @@ func BenchmarkCollisionDetection(b *testing.B) {
 		cs.CheckCollisions()
 	}
 }
+
+// testEntity is a minimal entity for exercising collision rules
+type testEntity struct {
+	entities.BaseEntity
+}
+
+func (e *testEntity) Update(dt float64) error {
+	return nil
+}
+
+func newPowerUp(x, y float64) *testEntity {
+	return &testEntity{entities.BaseEntity{
+		Position: vector.New(x, y),
+		Active:   true,
+		Type:     entities.TypePowerUp,
+		Radius:   8,
+	}}
+}
+
+func TestDefaultLayerTableMatchesRules(t *testing.T) {
+	table := DefaultLayerTable()
+
+	tests := []struct {
+		a, b Layer
+		want bool
+	}{
+		{LayerPlayer, LayerEnemy, true},
+		{LayerPlayer, LayerPlayerBullet, false},
+		{LayerPlayer, LayerEnemyBullet, false},
+		{LayerPlayerBullet, LayerEnemy, true},
+		{LayerPlayerBullet, LayerEnemyBullet, false},
+		{LayerEnemy, LayerEnemy, false},
+		{LayerParticle, LayerPlayer, false},
+		{LayerPowerUp, LayerPlayer, true},
+	}
+	for _, tt := range tests {
+		if got := table.Collides(tt.a, tt.b); got != tt.want {
+			t.Errorf("Collides(%b, %b) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := table.Collides(tt.b, tt.a); got != tt.want {
+			t.Errorf("Collides(%b, %b) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestLayerTableIgnoresNonLayers(t *testing.T) {
+	table := DefaultLayerTable()
+	playerMask := table.Mask(LayerPlayer)
+
+	table.SetMask(LayerNone, LayerParticle)
+	table.Enable(LayerNone, LayerEnemy)
+	table.SetMask(LayerPlayer|LayerEnemy, LayerNone)
+	if table.Mask(LayerPlayer) != playerMask {
+		t.Errorf("Expected the player's mask left alone, got %b", table.Mask(LayerPlayer))
+	}
+	if table.Collides(LayerNone, LayerEnemy) || table.Mask(LayerNone) != LayerNone {
+		t.Error("Expected nothing to collide with no layer")
+	}
+	if table.Collides(LayerPlayer|LayerPowerUp, LayerEnemy) {
+		t.Error("Expected a mask of several layers not to collide as a layer")
+	}
+}
+
+func TestEnemyBulletVsPlayer(t *testing.T) {
+	player := entities.NewPlayer(100, 100, 800, 600, testPlayerConfig)
+	bullet := entities.NewBullet(100, 100, vector.Zero(), 10, entities.OwnerEnemy, 800, 600)
+
+	if LayerOf(bullet) != LayerEnemyBullet {
+		t.Fatalf("Expected enemy bullet layer, got %b", LayerOf(bullet))
+	}
+
+	cs := NewCollisionSystem()
+	cs.AddEntity(player)
+	cs.AddEntity(bullet)
+	if len(cs.CheckCollisions()) != 0 {
+		t.Error("Default table should not let enemy bullets hit the player")
+	}
+
+	table := DefaultLayerTable()
+	table.Enable(LayerEnemyBullet, LayerPlayer)
+	cs = NewCollisionSystem(WithLayers(table))
+	cs.AddEntity(player)
+	cs.AddEntity(bullet)
+	if len(cs.CheckCollisions()) != 1 {
+		t.Error("Expected enemy bullet to hit the player when enabled")
+	}
+
+	// Player bullets still pass through the player
+	own := entities.NewBullet(100, 100, vector.Zero(), 10, entities.OwnerPlayer, 800, 600)
+	cs.Clear()
+	cs.AddEntity(player)
+	cs.AddEntity(own)
+	if len(cs.CheckCollisions()) != 0 {
+		t.Error("Player bullets should not hit the player")
+	}
+}
+
+func TestPowerUpVsPlayer(t *testing.T) {
+	player := entities.NewPlayer(100, 100, 800, 600, testPlayerConfig)
+	powerUp := newPowerUp(110, 100)
+
+	cs := NewCollisionSystem()
+	cs.AddEntity(player)
+	cs.AddEntity(powerUp)
+	if len(cs.CheckCollisions()) != 1 {
+		t.Error("Expected power-up to touch the player")
+	}
+
+	table := DefaultLayerTable()
+	table.SetMask(LayerPowerUp, LayerNone)
+	cs = NewCollisionSystem(WithLayers(table))
+	cs.AddEntity(player)
+	cs.AddEntity(powerUp)
+	if len(cs.CheckCollisions()) != 0 {
+		t.Error("Power-up with an empty mask should not collide")
+	}
+}
+
+// populate fills cs with a bullet-hell mix of enemies and player bullets
+// spread over an 800x600 screen
+func populate(cs *CollisionSystem, n int) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < n; i++ {
+		x, y := rng.Float64()*800, rng.Float64()*600
+		if i%5 == 0 {
+			cs.AddEntity(entities.NewEnemy(entities.EnemyBasic, x, y, 800, 600, testBasicEnemy))
+		} else {
+			cs.AddEntity(entities.NewBullet(x, y, vector.Zero(), 10, entities.OwnerPlayer, 800, 600))
+		}
+	}
+}
+
+func pairSet(pairs []CollisionPair) map[CollisionPair]bool {
+	set := make(map[CollisionPair]bool, len(pairs))
+	for _, p := range pairs {
+		set[p] = true
+	}
+	return set
+}
+
+func TestSpatialHashMatchesBruteForce(t *testing.T) {
+	for _, n := range []int{10, 500, 2000} {
+		cs := NewCollisionSystem()
+		populate(cs, n)
+
+		want := pairSet(cs.checkBruteForce())
+		got := cs.CheckCollisions()
+
+		if len(got) != len(want) {
+			t.Errorf("n=%d: expected %d pairs, got %d", n, len(want), len(got))
+		}
+		seen := make(map[CollisionPair]bool)
+		for _, p := range got {
+			if seen[p] {
+				t.Errorf("n=%d: pair reported twice", n)
+			}
+			seen[p] = true
+			if !want[p] {
+				t.Errorf("n=%d: unexpected pair", n)
+			}
+		}
+	}
+}
+
+func TestLargeEntitySpanningCells(t *testing.T) {
+	cs := NewCollisionSystem(WithCellSize(8))
+
+	player := entities.NewPlayer(100, 100, 800, 600, testPlayerConfig)
+	enemy := entities.NewEnemy(entities.EnemyTank, 130, 100, 800, 600, testBasicEnemy)
+	cs.AddEntity(player)
+	cs.AddEntity(enemy)
+
+	if n := len(cs.CheckCollisions()); n != 1 {
+		t.Errorf("Expected exactly 1 collision, got %d", n)
+	}
+}
+
+func TestCheckCollisionsReusesBuffers(t *testing.T) {
+	cs := NewCollisionSystem()
+	populate(cs, 1000)
+	cs.CheckCollisions()
+
+	allocs := testing.AllocsPerRun(10, func() {
+		cs.CheckCollisions()
+	})
+	if allocs > 0 {
+		t.Errorf("Expected no allocations per frame, got %g", allocs)
+	}
+}
+
+func TestQuerySegment(t *testing.T) {
+	cs := NewCollisionSystem()
+	inLine := entities.NewEnemy(entities.EnemyBasic, 400, 100, 800, 600, testBasicEnemy)
+	alsoInLine := entities.NewEnemy(entities.EnemyBasic, 410, 300, 800, 600, testBasicEnemy)
+	offLine := entities.NewEnemy(entities.EnemyBasic, 600, 200, 800, 600, testBasicEnemy)
+	player := entities.NewPlayer(400, 500, 800, 600, testPlayerConfig)
+	for _, e := range []entities.Entity{inLine, alsoInLine, offLine, player} {
+		cs.AddEntity(e)
+	}
+	cs.CheckCollisions()
+
+	hits := cs.QuerySegment(vector.New(400, 470), vector.New(400, 0), 3, LayerPlayerBullet, nil)
+
+	if len(hits) != 2 || hits[0] != entities.Entity(inLine) || hits[1] != entities.Entity(alsoInLine) {
+		t.Errorf("Expected both enemies in line and nothing else, got %d hits", len(hits))
+	}
+}
+
+func BenchmarkBroadPhase(b *testing.B) {
+	for _, n := range []int{100, 1000, 10000} {
+		cs := NewCollisionSystem()
+		populate(cs, n)
+
+		b.Run(fmt.Sprintf("SpatialHash/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				cs.CheckCollisions()
+			}
+		})
+		b.Run(fmt.Sprintf("BruteForce/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				cs.checkBruteForce()
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package physics
+
+import (
+	"math/bits"
+
+	"github.com/EchoSingh/space-shooter/internal/entities"
+)
+
+// Layer is a collision layer. Each layer is a single bit so layers can be
+// combined into masks.
+type Layer uint32
+
+const (
+	LayerPlayer Layer = 1 << iota
+	LayerEnemy
+	LayerPlayerBullet
+	LayerEnemyBullet
+	LayerPowerUp
+	LayerParticle
+)
+
+// LayerNone is the empty mask
+const LayerNone Layer = 0
+
+// Layered is implemented by entities that choose their own collision layer
+type Layered interface {
+	CollisionLayer() Layer
+}
+
+// LayerOf returns the collision layer of an entity.
+// Entities that don't implement Layered are assigned a layer by type,
+// with bullets split by owner.
+func LayerOf(e entities.Entity) Layer {
+	if l, ok := e.(Layered); ok {
+		return l.CollisionLayer()
+	}
+
+	switch e.GetType() {
+	case entities.TypePlayer:
+		return LayerPlayer
+	case entities.TypeEnemy:
+		return LayerEnemy
+	case entities.TypeBullet:
+		if b, ok := e.(interface{ GetOwner() entities.BulletOwner }); ok && b.GetOwner() == entities.OwnerEnemy {
+			return LayerEnemyBullet
+		}
+		return LayerPlayerBullet
+	case entities.TypePowerUp:
+		return LayerPowerUp
+	}
+	return LayerParticle
+}
+
+// LayerTable holds, for each layer, the mask of layers it collides with.
+// Two entities collide only if each one's mask includes the other's layer.
+// Anything passed as a layer that isn't exactly one bit, such as
+// LayerNone or a mask of several layers, is ignored and never collides.
+type LayerTable struct {
+	masks [32]Layer
+}
+
+// NewLayerTable creates a table in which nothing collides
+func NewLayerTable() *LayerTable {
+	return &LayerTable{}
+}
+
+// DefaultLayerTable returns the game's standard collision rules:
+// bullets never hit the player or each other, enemies never hit each
+// other and particles never collide with anything.
+func DefaultLayerTable() *LayerTable {
+	t := NewLayerTable()
+	t.Enable(LayerPlayer, LayerEnemy)
+	t.Enable(LayerPlayer, LayerPowerUp)
+	t.Enable(LayerEnemy, LayerPlayerBullet)
+	t.Enable(LayerEnemy, LayerEnemyBullet)
+	t.Enable(LayerEnemy, LayerPowerUp)
+	t.Enable(LayerPowerUp, LayerPlayerBullet)
+	t.Enable(LayerPowerUp, LayerEnemyBullet)
+	t.Enable(LayerPowerUp, LayerPowerUp)
+	return t
+}
+
+// SetMask sets the layers that layer collides with
+func (t *LayerTable) SetMask(layer, mask Layer) {
+	if i, ok := index(layer); ok {
+		t.masks[i] = mask
+	}
+}
+
+// Mask returns the layers that layer collides with
+func (t *LayerTable) Mask(layer Layer) Layer {
+	if i, ok := index(layer); ok {
+		return t.masks[i]
+	}
+	return LayerNone
+}
+
+// Enable makes two layers collide with each other
+func (t *LayerTable) Enable(a, b Layer) {
+	i, okA := index(a)
+	j, okB := index(b)
+	if okA && okB {
+		t.masks[i] |= b
+		t.masks[j] |= a
+	}
+}
+
+// Disable stops two layers colliding with each other
+func (t *LayerTable) Disable(a, b Layer) {
+	i, okA := index(a)
+	j, okB := index(b)
+	if okA && okB {
+		t.masks[i] &^= b
+		t.masks[j] &^= a
+	}
+}
+
+// Collides reports whether entities on layers a and b collide
+func (t *LayerTable) Collides(a, b Layer) bool {
+	return t.Mask(a)&b != 0 && t.Mask(b)&a != 0
+}
+
+// Clone returns a copy of the table
+func (t *LayerTable) Clone() *LayerTable {
+	c := *t
+	return &c
+}
+
+// index returns layer's slot in the table, or false if layer isn't a
+// single layer
+func index(layer Layer) (int, bool) {
+	if bits.OnesCount32(uint32(layer)) != 1 {
+		return 0, false
+	}
+	return bits.TrailingZeros32(uint32(layer)), true
+}
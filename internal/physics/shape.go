@@ -0,0 +1,295 @@
+package physics
+
+import (
+	"math"
+
+	"github.com/EchoSingh/space-shooter/internal/entities"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+// Shape is a collision shape centred on an entity's position.
+// The supported shapes are Circle, Box, OrientedBox and Polygon.
+type Shape interface {
+	// Bounds returns the shape's bounding box when centred at pos
+	Bounds(pos vector.Vector2) AABB
+}
+
+// Shaped is implemented by entities that provide their own collision shape
+type Shaped interface {
+	CollisionShape() Shape
+}
+
+// Circle is a circle of the given radius
+type Circle struct {
+	Radius float64
+}
+
+// Box is an axis-aligned rectangle
+type Box struct {
+	HalfWidth  float64
+	HalfHeight float64
+}
+
+// OrientedBox is a rectangle rotated by Angle radians
+type OrientedBox struct {
+	HalfWidth  float64
+	HalfHeight float64
+	Angle      float64
+}
+
+// Polygon is a convex polygon rotated by Angle radians.
+// Points are relative to the entity's position, in either winding order.
+type Polygon struct {
+	Points []vector.Vector2
+	Angle  float64
+}
+
+// Bounds returns the circle's bounding box
+func (c Circle) Bounds(pos vector.Vector2) AABB {
+	return AABB{
+		Min: pos.Sub(vector.New(c.Radius, c.Radius)),
+		Max: pos.Add(vector.New(c.Radius, c.Radius)),
+	}
+}
+
+// Bounds returns the box itself
+func (b Box) Bounds(pos vector.Vector2) AABB {
+	return AABB{
+		Min: pos.Sub(vector.New(b.HalfWidth, b.HalfHeight)),
+		Max: pos.Add(vector.New(b.HalfWidth, b.HalfHeight)),
+	}
+}
+
+// Bounds returns the box enclosing the rotated rectangle
+func (b OrientedBox) Bounds(pos vector.Vector2) AABB {
+	var buf [4]vector.Vector2
+	return boundsOfPoints(appendBox(buf[:0], pos, b.HalfWidth, b.HalfHeight, b.Angle))
+}
+
+// Bounds returns the box enclosing the polygon
+func (p Polygon) Bounds(pos vector.Vector2) AABB {
+	if len(p.Points) == 0 {
+		return AABB{Min: pos, Max: pos}
+	}
+	var buf [8]vector.Vector2
+	return boundsOfPoints(appendPolygon(buf[:0], pos, p.Points, p.Angle))
+}
+
+// ShapeOf returns an entity's collision shape. Entities implementing
+// Shaped provide their own, entities with a hitbox use it, and all
+// others fall back to a circle of their radius.
+func ShapeOf(e entities.Entity) Shape {
+	if s, ok := e.(Shaped); ok {
+		return s.CollisionShape()
+	}
+	if h, ok := e.(hitboxed); ok {
+		if hb := h.GetHitbox(); hb != nil {
+			return shapeOfHitbox(hb)
+		}
+	}
+	return Circle{Radius: e.GetRadius()}
+}
+
+// Intersects reports whether shape a centred at posA overlaps shape b centred at posB
+func Intersects(a Shape, posA vector.Vector2, b Shape, posB vector.Vector2) bool {
+	var bufA, bufB [8]vector.Vector2
+	ca := makeCollider(a, posA, bufA[:0])
+	cb := makeCollider(b, posB, bufB[:0])
+	return ca.overlaps(&cb)
+}
+
+type hitboxed interface {
+	GetHitbox() *entities.Hitbox
+}
+
+func shapeOfHitbox(h *entities.Hitbox) Shape {
+	switch {
+	case len(h.Points) > 0:
+		return Polygon{Points: h.Points, Angle: h.Angle}
+	case h.Angle != 0:
+		return OrientedBox{HalfWidth: h.Width / 2, HalfHeight: h.Height / 2, Angle: h.Angle}
+	default:
+		return Box{HalfWidth: h.Width / 2, HalfHeight: h.Height / 2}
+	}
+}
+
+func appendHitbox(dst []vector.Vector2, pos vector.Vector2, h *entities.Hitbox) []vector.Vector2 {
+	if len(h.Points) > 0 {
+		return appendPolygon(dst, pos, h.Points, h.Angle)
+	}
+	return appendBox(dst, pos, h.Width/2, h.Height/2, h.Angle)
+}
+
+// collider is a shape placed in the world. Circles have no vertices;
+// everything else is a convex polygon in world space. Swept circles
+// cover the whole path from prev to center.
+type collider struct {
+	center vector.Vector2
+	prev   vector.Vector2
+	radius float64
+	verts  []vector.Vector2
+	swept  bool
+}
+
+func makeCollider(s Shape, pos vector.Vector2, buf []vector.Vector2) collider {
+	c := collider{center: pos, prev: pos}
+	switch s := s.(type) {
+	case Circle:
+		c.radius = s.Radius
+	case *Circle:
+		c.radius = s.Radius
+	case Box:
+		c.verts = appendBox(buf, pos, s.HalfWidth, s.HalfHeight, 0)
+	case *Box:
+		c.verts = appendBox(buf, pos, s.HalfWidth, s.HalfHeight, 0)
+	case OrientedBox:
+		c.verts = appendBox(buf, pos, s.HalfWidth, s.HalfHeight, s.Angle)
+	case *OrientedBox:
+		c.verts = appendBox(buf, pos, s.HalfWidth, s.HalfHeight, s.Angle)
+	case Polygon:
+		c.verts = appendPolygon(buf, pos, s.Points, s.Angle)
+	case *Polygon:
+		c.verts = appendPolygon(buf, pos, s.Points, s.Angle)
+	default:
+		// Unknown shapes collide by their bounds
+		b := s.Bounds(pos)
+		c.verts = append(buf, b.Min, vector.New(b.Max.X, b.Min.Y), b.Max, vector.New(b.Min.X, b.Max.Y))
+	}
+
+	// Degenerate polygons collide as a point
+	if c.verts != nil && len(c.verts) < 3 {
+		c.verts = nil
+	}
+	return c
+}
+
+func (c *collider) bounds() AABB {
+	if c.verts == nil {
+		b := Circle{Radius: c.radius}.Bounds(c.center)
+		if c.swept {
+			p := Circle{Radius: c.radius}.Bounds(c.prev)
+			b.Min = vector.New(math.Min(b.Min.X, p.Min.X), math.Min(b.Min.Y, p.Min.Y))
+			b.Max = vector.New(math.Max(b.Max.X, p.Max.X), math.Max(b.Max.Y, p.Max.Y))
+		}
+		return b
+	}
+	return boundsOfPoints(c.verts)
+}
+
+func (c *collider) overlaps(o *collider) bool {
+	switch {
+	case c.swept:
+		return sweptOverlaps(c, o)
+	case o.swept:
+		return sweptOverlaps(o, c)
+	case c.verts == nil && o.verts == nil:
+		r := c.radius + o.radius
+		return c.center.DistanceSquared(o.center) <= r*r
+	case c.verts == nil:
+		return circleOverlapsPolygon(c.center, c.radius, o.verts)
+	case o.verts == nil:
+		return circleOverlapsPolygon(o.center, o.radius, c.verts)
+	default:
+		return polygonsOverlap(c.verts, o.verts)
+	}
+}
+
+// polygonsOverlap runs the separating axis test on two convex polygons
+func polygonsOverlap(a, b []vector.Vector2) bool {
+	return !hasSeparatingEdge(a, a, b) && !hasSeparatingEdge(b, a, b)
+}
+
+// hasSeparatingEdge reports whether any edge normal of poly separates a and b
+func hasSeparatingEdge(poly, a, b []vector.Vector2) bool {
+	for i := range poly {
+		edge := poly[(i+1)%len(poly)].Sub(poly[i])
+		axis := vector.New(-edge.Y, edge.X)
+		minA, maxA := project(a, axis)
+		minB, maxB := project(b, axis)
+		if maxA < minB || maxB < minA {
+			return true
+		}
+	}
+	return false
+}
+
+// circleOverlapsPolygon runs the separating axis test on a circle and a
+// convex polygon, using the polygon's edge normals plus the axis from the
+// circle's centre to the nearest vertex
+func circleOverlapsPolygon(center vector.Vector2, radius float64, poly []vector.Vector2) bool {
+	nearest := poly[0]
+	for _, v := range poly[1:] {
+		if v.DistanceSquared(center) < nearest.DistanceSquared(center) {
+			nearest = v
+		}
+	}
+
+	separated := func(axis vector.Vector2) bool {
+		axis = axis.Normalize()
+		if axis.X == 0 && axis.Y == 0 {
+			return false
+		}
+		minP, maxP := project(poly, axis)
+		c := center.Dot(axis)
+		return c+radius < minP || c-radius > maxP
+	}
+
+	if separated(center.Sub(nearest)) {
+		return false
+	}
+	for i := range poly {
+		edge := poly[(i+1)%len(poly)].Sub(poly[i])
+		if separated(vector.New(-edge.Y, edge.X)) {
+			return false
+		}
+	}
+	return true
+}
+
+func project(poly []vector.Vector2, axis vector.Vector2) (min, max float64) {
+	min = poly[0].Dot(axis)
+	max = min
+	for _, v := range poly[1:] {
+		d := v.Dot(axis)
+		min = math.Min(min, d)
+		max = math.Max(max, d)
+	}
+	return min, max
+}
+
+func appendBox(dst []vector.Vector2, pos vector.Vector2, hw, hh, angle float64) []vector.Vector2 {
+	corners := [4]vector.Vector2{
+		{X: -hw, Y: -hh},
+		{X: hw, Y: -hh},
+		{X: hw, Y: hh},
+		{X: -hw, Y: hh},
+	}
+	for _, c := range corners {
+		if angle != 0 {
+			c = c.Rotate(angle)
+		}
+		dst = append(dst, pos.Add(c))
+	}
+	return dst
+}
+
+func appendPolygon(dst []vector.Vector2, pos vector.Vector2, points []vector.Vector2, angle float64) []vector.Vector2 {
+	for _, p := range points {
+		if angle != 0 {
+			p = p.Rotate(angle)
+		}
+		dst = append(dst, pos.Add(p))
+	}
+	return dst
+}
+
+func boundsOfPoints(points []vector.Vector2) AABB {
+	b := AABB{Min: points[0], Max: points[0]}
+	for _, p := range points[1:] {
+		b.Min.X = math.Min(b.Min.X, p.X)
+		b.Min.Y = math.Min(b.Min.Y, p.Y)
+		b.Max.X = math.Max(b.Max.X, p.X)
+		b.Max.Y = math.Max(b.Max.Y, p.Y)
+	}
+	return b
+}
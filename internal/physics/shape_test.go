@@ -0,0 +1,116 @@
+package physics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/entities"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+func TestIntersects(t *testing.T) {
+	triangle := Polygon{Points: []vector.Vector2{{X: 0, Y: -10}, {X: 10, Y: 10}, {X: -10, Y: 10}}}
+
+	tests := []struct {
+		name   string
+		a      Shape
+		posA   vector.Vector2
+		b      Shape
+		posB   vector.Vector2
+		expect bool
+	}{
+		{"circles touching", Circle{5}, vector.New(0, 0), Circle{5}, vector.New(10, 0), true},
+		{"circles apart", Circle{5}, vector.New(0, 0), Circle{5}, vector.New(11, 0), false},
+		{"circle beside box", Circle{5}, vector.New(14, 0), Box{10, 10}, vector.New(0, 0), true},
+		{"circle off box corner", Circle{5}, vector.New(14, 14), Box{10, 10}, vector.New(0, 0), false},
+		{"boxes overlapping", Box{10, 5}, vector.New(0, 0), Box{10, 5}, vector.New(19, 9), true},
+		{"boxes apart", Box{10, 5}, vector.New(0, 0), Box{10, 5}, vector.New(0, 11), false},
+		{"rotated box misses corner", OrientedBox{10, 10, math.Pi / 4}, vector.New(0, 0), Box{2, 2}, vector.New(11, 11), false},
+		{"rotated box reaches along diagonal", OrientedBox{10, 10, math.Pi / 4}, vector.New(0, 0), Box{2, 2}, vector.New(14, 0), true},
+		{"circle beside triangle slope", Circle{2}, vector.New(8, -6), triangle, vector.New(0, 0), false},
+		{"circle inside triangle", Circle{2}, vector.New(0, 5), triangle, vector.New(0, 0), true},
+		{"triangle vs box", triangle, vector.New(0, 0), Box{5, 5}, vector.New(0, -14), true},
+		{"triangle tip misses box", triangle, vector.New(0, 0), Box{5, 5}, vector.New(-14, -10), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Intersects(tt.a, tt.posA, tt.b, tt.posB); got != tt.expect {
+				t.Errorf("Intersects = %v, want %v", got, tt.expect)
+			}
+			if got := Intersects(tt.b, tt.posB, tt.a, tt.posA); got != tt.expect {
+				t.Errorf("Intersects (swapped) = %v, want %v", got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestShapeOf(t *testing.T) {
+	bullet := entities.NewBullet(0, 0, vector.Zero(), 10, entities.OwnerPlayer, 800, 600)
+	if s, ok := ShapeOf(bullet).(Circle); !ok || s.Radius != bullet.GetRadius() {
+		t.Errorf("Expected bullet to fall back to its radius, got %#v", ShapeOf(bullet))
+	}
+
+	player := entities.NewPlayer(0, 0, 800, 600, testPlayerConfig)
+	if s, ok := ShapeOf(player).(Box); !ok || s.HalfWidth != 22.5 || s.HalfHeight != 27.5 {
+		t.Errorf("Expected player box hitbox, got %#v", ShapeOf(player))
+	}
+
+	player.Visual.Angle = 0.5
+	if _, ok := ShapeOf(player).(OrientedBox); !ok {
+		t.Errorf("Expected rotated player to have an oriented box, got %#v", ShapeOf(player))
+	}
+}
+
+type shapedEntity struct {
+	entities.BaseEntity
+	shape Shape
+}
+
+func (e *shapedEntity) Update(dt float64) error      { return nil }
+func (e *shapedEntity) CollisionShape() Shape        { return e.shape }
+func (e *shapedEntity) CollisionLayer() Layer        { return LayerEnemy }
+func (e *shapedEntity) GetType() entities.EntityType { return entities.TypeEnemy }
+
+func TestPlayerHitboxMatchesShip(t *testing.T) {
+	player := entities.NewPlayer(100, 100, 800, 600, testPlayerConfig)
+
+	// Just inside the ship's nose, beyond its old collision radius
+	enemy := &shapedEntity{
+		BaseEntity: entities.BaseEntity{Position: vector.New(100, 100-27-2), Active: true, Radius: 3},
+		shape:      Circle{3},
+	}
+
+	cs := NewCollisionSystem()
+	cs.AddEntity(player)
+	cs.AddEntity(enemy)
+	if len(cs.CheckCollisions()) != 1 {
+		t.Error("Expected a hit on the ship's box hitbox")
+	}
+
+	// Diagonally off the corner of the ship
+	enemy.Position = vector.New(100+22.5+3, 100-27.5-3)
+	if len(cs.CheckCollisions()) != 0 {
+		t.Error("Expected no hit off the corner of the ship")
+	}
+}
+
+func TestShapedEntityPolygon(t *testing.T) {
+	wedge := &shapedEntity{
+		BaseEntity: entities.BaseEntity{Position: vector.New(200, 200), Active: true},
+		shape:      Polygon{Points: []vector.Vector2{{X: -30, Y: -5}, {X: 30, Y: -5}, {X: 0, Y: 25}}},
+	}
+	player := entities.NewPlayer(200, 250, 800, 600, testPlayerConfig)
+
+	cs := NewCollisionSystem()
+	cs.AddEntity(player)
+	cs.AddEntity(wedge)
+	if len(cs.CheckCollisions()) != 1 {
+		t.Error("Expected the wedge tip to reach the player")
+	}
+
+	player.Position = vector.New(245, 250)
+	if len(cs.CheckCollisions()) != 0 {
+		t.Error("Expected the player to clear the wedge's slope")
+	}
+}
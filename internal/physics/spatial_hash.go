@@ -0,0 +1,123 @@
+package physics
+
+import (
+	"math"
+
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+// DefaultCellSize is the grid cell size used by NewCollisionSystem.
+// It is a little larger than the biggest regular enemy.
+const DefaultCellSize = 64.0
+
+// AABB is an axis-aligned bounding box
+type AABB struct {
+	Min vector.Vector2
+	Max vector.Vector2
+}
+
+// Overlaps reports whether two boxes intersect
+func (b AABB) Overlaps(other AABB) bool {
+	return b.Min.X <= other.Max.X && b.Max.X >= other.Min.X &&
+		b.Min.Y <= other.Max.Y && b.Max.Y >= other.Min.Y
+}
+
+type cellKey struct {
+	x, y int32
+}
+
+// SpatialHash is a uniform grid broad phase.
+// Items are inserted into every cell their bounds overlap, and each
+// candidate pair is reported once, from the cell holding the top-left
+// corner of the pair's overlap. All storage is reused between frames.
+type SpatialHash struct {
+	cellSize float64
+	cells    map[cellKey]int
+	keys     []cellKey
+	buckets  [][]int
+	bounds   []AABB
+}
+
+// NewSpatialHash creates a spatial hash with square cells of the given size
+func NewSpatialHash(cellSize float64) *SpatialHash {
+	if cellSize <= 0 {
+		cellSize = DefaultCellSize
+	}
+	return &SpatialHash{
+		cellSize: cellSize,
+		cells:    make(map[cellKey]int),
+	}
+}
+
+// Clear removes all items while keeping allocated storage
+func (h *SpatialHash) Clear() {
+	clear(h.cells)
+	for i := range h.buckets {
+		h.buckets[i] = h.buckets[i][:0]
+	}
+	h.keys = h.keys[:0]
+	h.bounds = h.bounds[:0]
+}
+
+// Insert adds item with the given bounds.
+// Items must be inserted with consecutive indices starting at 0.
+func (h *SpatialHash) Insert(item int, bounds AABB) {
+	for len(h.bounds) <= item {
+		h.bounds = append(h.bounds, AABB{})
+	}
+	h.bounds[item] = bounds
+
+	min, max := h.cell(bounds.Min), h.cell(bounds.Max)
+	for y := min.y; y <= max.y; y++ {
+		for x := min.x; x <= max.x; x++ {
+			key := cellKey{x, y}
+			idx, ok := h.cells[key]
+			if !ok {
+				idx = len(h.keys)
+				h.cells[key] = idx
+				h.keys = append(h.keys, key)
+				if idx == len(h.buckets) {
+					h.buckets = append(h.buckets, nil)
+				}
+			}
+			h.buckets[idx] = append(h.buckets[idx], item)
+		}
+	}
+}
+
+// Pairs calls fn once for every pair of items whose bounds overlap, with a < b.
+// Pairs are visited in a deterministic order.
+func (h *SpatialHash) Pairs(fn func(a, b int)) {
+	for idx, key := range h.keys {
+		bucket := h.buckets[idx]
+		for i := 0; i < len(bucket); i++ {
+			a := bucket[i]
+			for j := i + 1; j < len(bucket); j++ {
+				b := bucket[j]
+				ba, bb := h.bounds[a], h.bounds[b]
+				if !ba.Overlaps(bb) {
+					continue
+				}
+
+				// Only the cell holding the overlap's corner reports the pair
+				corner := h.cell(vector.New(math.Max(ba.Min.X, bb.Min.X), math.Max(ba.Min.Y, bb.Min.Y)))
+				if corner != key {
+					continue
+				}
+
+				if a < b {
+					fn(a, b)
+				} else {
+					fn(b, a)
+				}
+			}
+		}
+	}
+}
+
+func (h *SpatialHash) cell(p vector.Vector2) cellKey {
+	return cellKey{
+		x: int32(math.Floor(p.X / h.cellSize)),
+		y: int32(math.Floor(p.Y / h.cellSize)),
+	}
+}
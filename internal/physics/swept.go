@@ -0,0 +1,104 @@
+package physics
+
+import (
+	"math"
+
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+// FastMover is implemented by entities that can move further than their
+// own size in a single tick. When IsFastMover reports true the entity is
+// tested along the whole path from its previous position, treating it
+// as a circle swept along that segment, so it cannot tunnel through
+// small targets at large timesteps.
+type FastMover interface {
+	IsFastMover() bool
+	GetPreviousPosition() vector.Vector2
+}
+
+// sweptOverlaps tests a swept circle against another collider.
+// c must be the swept one.
+func sweptOverlaps(c, o *collider) bool {
+	if o.verts != nil {
+		return segmentPolygonDistanceSq(c.prev, c.center, o.verts) <= c.radius*c.radius
+	}
+
+	// Circle against circle: move into o's frame so only c moves
+	r := c.radius + o.radius
+	start := c.prev.Sub(o.prev)
+	end := c.center.Sub(o.center)
+	return pointSegmentDistanceSq(vector.Zero(), start, end) <= r*r
+}
+
+// pointSegmentDistanceSq returns the squared distance from p to segment ab
+func pointSegmentDistanceSq(p, a, b vector.Vector2) float64 {
+	ab := b.Sub(a)
+	lenSq := ab.LengthSquared()
+	if lenSq == 0 {
+		return p.DistanceSquared(a)
+	}
+	t := math.Max(0, math.Min(1, p.Sub(a).Dot(ab)/lenSq))
+	return p.DistanceSquared(a.Add(ab.Mul(t)))
+}
+
+// segmentsIntersect reports whether segments ab and cd cross or touch
+func segmentsIntersect(a, b, c, d vector.Vector2) bool {
+	d1 := cross(d.Sub(c), a.Sub(c))
+	d2 := cross(d.Sub(c), b.Sub(c))
+	d3 := cross(b.Sub(a), c.Sub(a))
+	d4 := cross(b.Sub(a), d.Sub(a))
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return d1 == 0 && onSegment(c, d, a) ||
+		d2 == 0 && onSegment(c, d, b) ||
+		d3 == 0 && onSegment(a, b, c) ||
+		d4 == 0 && onSegment(a, b, d)
+}
+
+// onSegment reports whether p, known to be collinear with ab, lies on it
+func onSegment(a, b, p vector.Vector2) bool {
+	return math.Min(a.X, b.X) <= p.X && p.X <= math.Max(a.X, b.X) &&
+		math.Min(a.Y, b.Y) <= p.Y && p.Y <= math.Max(a.Y, b.Y)
+}
+
+// pointInPolygon reports whether p lies inside a convex polygon
+func pointInPolygon(p vector.Vector2, poly []vector.Vector2) bool {
+	sign := 0.0
+	for i := range poly {
+		c := cross(poly[(i+1)%len(poly)].Sub(poly[i]), p.Sub(poly[i]))
+		if c == 0 {
+			continue
+		}
+		if sign == 0 {
+			sign = c
+		} else if (c > 0) != (sign > 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// segmentPolygonDistanceSq returns the squared distance between segment ab
+// and a convex polygon, which is zero when they overlap
+func segmentPolygonDistanceSq(a, b vector.Vector2, poly []vector.Vector2) float64 {
+	if pointInPolygon(a, poly) {
+		return 0
+	}
+
+	best := math.Inf(1)
+	for i := range poly {
+		p, q := poly[i], poly[(i+1)%len(poly)]
+		if segmentsIntersect(a, b, p, q) {
+			return 0
+		}
+		best = math.Min(best, pointSegmentDistanceSq(a, p, q))
+		best = math.Min(best, pointSegmentDistanceSq(b, p, q))
+		best = math.Min(best, pointSegmentDistanceSq(p, a, b))
+	}
+	return best
+}
+
+func cross(a, b vector.Vector2) float64 {
+	return a.X*b.Y - a.Y*b.X
+}
@@ -0,0 +1,92 @@
+package physics
+
+import (
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/entities"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+var testFastEnemy, _ = config.Default().Enemies.EnemyType(config.EnemyFast)
+
+// fireThrough steps a player bullet upward past an enemy with the given
+// timestep and reports whether any frame detected a hit
+func fireThrough(t *testing.T, dt float64, enemyX float64, swept bool) bool {
+	t.Helper()
+
+	enemy := entities.NewEnemy(entities.EnemyFast, enemyX, 300, 800, 600, testFastEnemy)
+	bullet := entities.NewBullet(400, 500, vector.New(0, -500), 10, entities.OwnerPlayer, 800, 600)
+	bullet.FastMover = swept
+
+	cs := NewCollisionSystem()
+	for bullet.IsActive() {
+		_ = bullet.Update(dt)
+
+		cs.Clear()
+		cs.AddEntity(enemy)
+		cs.AddEntity(bullet)
+		if len(cs.CheckCollisions()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNoTunnellingAtLargeTimestep(t *testing.T) {
+	for _, dt := range []float64{1.0 / 60, 0.1, 0.25, 0.5} {
+		if !fireThrough(t, dt, 400, true) {
+			t.Errorf("dt=%g: swept bullet tunnelled through a fast enemy", dt)
+		}
+	}
+}
+
+func TestUnsweptBulletTunnels(t *testing.T) {
+	// Demonstrates the problem swept collision solves: at 0.25s per tick a
+	// bullet jumps 125px, right over a 20px enemy
+	if fireThrough(t, 0.25, 400, false) {
+		t.Error("Expected an unswept bullet to skip over the enemy")
+	}
+}
+
+func TestSweptBulletMisses(t *testing.T) {
+	if fireThrough(t, 0.25, 440, true) {
+		t.Error("Swept bullet passing beside the enemy should not hit")
+	}
+}
+
+func TestSweptAgainstMovingCircle(t *testing.T) {
+	a := entities.NewBullet(0, 0, vector.New(600, 0), 10, entities.OwnerPlayer, 800, 600)
+	b := &testEntity{entities.BaseEntity{Position: vector.New(50, -50), Active: true, Type: entities.TypeEnemy, Radius: 5}}
+
+	// Bullet sweeps from (0,0) to (100,0); the circle sits on its path at (50,0)
+	_ = a.Update(100.0 / 600)
+	b.Position = vector.New(50, 0)
+
+	cs := NewCollisionSystem()
+	cs.AddEntity(a)
+	cs.AddEntity(b)
+	if len(cs.CheckCollisions()) != 1 {
+		t.Error("Expected swept bullet to hit a circle on its path")
+	}
+}
+
+func TestSegmentPolygonDistance(t *testing.T) {
+	square := appendBox(nil, vector.Zero(), 10, 10, 0)
+
+	tests := []struct {
+		name string
+		a, b vector.Vector2
+		want float64
+	}{
+		{"crossing", vector.New(-20, 0), vector.New(20, 0), 0},
+		{"inside", vector.New(-1, 0), vector.New(1, 0), 0},
+		{"beside", vector.New(15, -20), vector.New(15, 20), 25},
+		{"past corner", vector.New(13, 14), vector.New(20, 14), 9 + 16},
+	}
+	for _, tt := range tests {
+		if got := segmentPolygonDistanceSq(tt.a, tt.b, square); got != tt.want {
+			t.Errorf("%s: got %g, want %g", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+// Package postfx runs the finished scene through a chain of Kage shader
+// effects on its way to the screen: bloom, chromatic aberration and a
+// flash when the player is hurt, CRT scanlines and a vignette. Each
+// effect can be turned on and off. Where shaders can't be compiled the
+// scene is drawn as it is, with the flash as a plain overlay, so the game
+// still runs.
+package postfx
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/render"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Effect names, in the order the chain applies them
+const (
+	Bloom      = "bloom"
+	Aberration = "aberration"
+	Flash      = "flash"
+	CRT        = "crt"
+	Vignette   = "vignette"
+)
+
+// Effects returns every effect's name, in the order they are applied
+func Effects() []string {
+	return []string{Bloom, Aberration, Flash, CRT, Vignette}
+}
+
+//go:embed shaders/*.kage
+var shaderFS embed.FS
+
+// crtCurvature is how far the CRT screen bows out at its edges
+const crtCurvature = 0.03
+
+// flashColor is what the screen flashes towards when the player is hit,
+// and flashStrength how far it goes at the moment of the hit
+var flashColor = [3]float32{1, 0.2, 0.2}
+
+const flashStrength = 0.45
+
+// pass is one effect in the chain
+type pass struct {
+	name     string
+	enabled  bool
+	shader   *ebiten.Shader
+	uniforms map[string]any
+}
+
+// Chain applies the enabled effects in turn, drawing each into an
+// offscreen buffer and the last onto the screen. Effects tied to being
+// hit are skipped while they have nothing to show, and with nothing
+// enabled the scene goes straight to the screen.
+type Chain struct {
+	cfg    config.PostFXConfig
+	passes []*pass
+	hit    float64
+
+	loaded  bool
+	err     error
+	running bool
+	buffers [2]*ebiten.Image
+	op      ebiten.DrawRectShaderOptions
+}
+
+// New creates a chain with the effects cfg enables. Shaders are compiled
+// on first draw, once a graphics context exists.
+func New(cfg config.PostFXConfig) *Chain {
+	c := &Chain{cfg: cfg}
+	enabled := map[string]bool{
+		Bloom:      cfg.Bloom,
+		Aberration: cfg.Aberration,
+		Flash:      cfg.Flash,
+		CRT:        cfg.CRT,
+		Vignette:   cfg.Vignette,
+	}
+	for _, name := range Effects() {
+		c.passes = append(c.passes, &pass{name: name, enabled: enabled[name], uniforms: make(map[string]any)})
+	}
+	return c
+}
+
+// pass returns the named effect, or nil if there is no such effect
+func (c *Chain) pass(name string) *pass {
+	for _, p := range c.passes {
+		if p.name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// Enabled reports whether the named effect is on
+func (c *Chain) Enabled(name string) bool {
+	p := c.pass(name)
+	return p != nil && p.enabled
+}
+
+// SetEnabled turns the named effect on or off. Unknown names are ignored.
+func (c *Chain) SetEnabled(name string, enabled bool) {
+	if p := c.pass(name); p != nil {
+		p.enabled = enabled
+	}
+}
+
+// Toggle turns the named effect off if it is on, and on if it is off
+func (c *Chain) Toggle(name string) {
+	c.SetEnabled(name, !c.Enabled(name))
+}
+
+// Hit starts the flash and aberration for the player taking damage
+func (c *Chain) Hit() {
+	c.hit = 1
+}
+
+// Hitting reports whether the hit effects are still fading
+func (c *Chain) Hitting() bool {
+	return c.hit > 0
+}
+
+// Update fades the hit effects
+func (c *Chain) Update(dt float64) {
+	c.hit = max(0, c.hit-dt/c.cfg.FlashTime)
+}
+
+// Err returns why shaders are unavailable, or nil if they compiled or
+// haven't been needed yet
+func (c *Chain) Err() error {
+	return c.err
+}
+
+// active reports whether p has anything to draw this frame
+func (c *Chain) active(p *pass) bool {
+	if !p.enabled {
+		return false
+	}
+	switch p.name {
+	case Aberration, Flash:
+		return c.hit > 0
+	}
+	return true
+}
+
+// Begin returns the image the scene should be drawn onto this frame: an
+// offscreen buffer when any effect is active, or else screen itself
+func (c *Chain) Begin(screen *ebiten.Image) *ebiten.Image {
+	c.running = false
+	if !c.anyActive() {
+		return screen
+	}
+	if !c.loaded {
+		c.load()
+	}
+	if c.err != nil {
+		return screen
+	}
+
+	size := screen.Bounds().Size()
+	for i, buf := range c.buffers {
+		if buf == nil || buf.Bounds().Size() != size {
+			if buf != nil {
+				buf.Dispose()
+			}
+			c.buffers[i] = ebiten.NewImage(size.X, size.Y)
+		}
+	}
+	c.buffers[0].Clear()
+	c.running = true
+	return c.buffers[0]
+}
+
+// End runs the scene drawn since Begin through the active effects onto
+// screen. Without shaders it draws the flash as an overlay instead.
+func (c *Chain) End(screen *ebiten.Image) {
+	if !c.running {
+		c.drawFallback(screen)
+		return
+	}
+
+	remaining := 0
+	for _, p := range c.passes {
+		if c.active(p) {
+			remaining++
+		}
+	}
+
+	src := 0
+	for _, p := range c.passes {
+		if !c.active(p) {
+			continue
+		}
+		remaining--
+		dst := screen
+		if remaining > 0 {
+			dst = c.buffers[1-src]
+			dst.Clear()
+		}
+		c.apply(dst, c.buffers[src], p)
+		src = 1 - src
+	}
+}
+
+func (c *Chain) anyActive() bool {
+	for _, p := range c.passes {
+		if c.active(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// load compiles each effect's shader. If any fails the chain falls back
+// to drawing without shaders.
+func (c *Chain) load() {
+	c.loaded = true
+	for _, p := range c.passes {
+		src, err := shaderFS.ReadFile("shaders/" + p.name + ".kage")
+		if err != nil {
+			c.err = fmt.Errorf("postfx: reading %s shader: %w", p.name, err)
+			return
+		}
+		if p.shader, err = ebiten.NewShader(src); err != nil {
+			c.err = fmt.Errorf("postfx: compiling %s shader: %w", p.name, err)
+			return
+		}
+	}
+}
+
+// apply draws src onto dst through p's shader
+func (c *Chain) apply(dst, src *ebiten.Image, p *pass) {
+	c.setUniforms(p)
+	size := src.Bounds().Size()
+	c.op.Images[0] = src
+	c.op.Uniforms = p.uniforms
+	dst.DrawRectShader(size.X, size.Y, p.shader, &c.op)
+	c.op.Images[0] = nil
+}
+
+// setUniforms fills in p's shader variables for this frame
+func (c *Chain) setUniforms(p *pass) {
+	u := p.uniforms
+	switch p.name {
+	case Bloom:
+		u["Threshold"] = float32(c.cfg.BloomThreshold)
+		u["Intensity"] = float32(c.cfg.BloomIntensity)
+	case Aberration:
+		u["Amount"] = float32(c.cfg.AberrationAmount * c.hit)
+	case Flash:
+		u["Color"] = flashColor[:]
+		u["Amount"] = float32(flashStrength * c.hit)
+	case CRT:
+		u["Scanlines"] = float32(c.cfg.Scanlines)
+		u["Curvature"] = float32(crtCurvature)
+	case Vignette:
+		u["Strength"] = float32(c.cfg.VignetteStrength)
+	}
+}
+
+// drawFallback draws what it can without shaders: the flash, as a
+// translucent overlay
+func (c *Chain) drawFallback(screen *ebiten.Image) {
+	if !c.Enabled(Flash) || c.hit <= 0 {
+		return
+	}
+	a := float32(flashStrength * c.hit)
+	var cs ebiten.ColorScale
+	cs.Scale(flashColor[0]*a, flashColor[1]*a, flashColor[2]*a, a)
+	size := screen.Bounds().Size()
+	render.FillRectScale(screen, 0, 0, float64(size.X), float64(size.Y), cs)
+}
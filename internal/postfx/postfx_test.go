@@ -0,0 +1,95 @@
+package postfx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestShadersCompile(t *testing.T) {
+	c := New(config.Default().PostFX)
+	c.load()
+	if err := c.Err(); err != nil {
+		t.Fatalf("Expected every shader to compile, got %v", err)
+	}
+	for _, p := range c.passes {
+		if p.shader == nil {
+			t.Errorf("Expected a %s shader", p.name)
+		}
+	}
+}
+
+func TestEffectsFollowConfig(t *testing.T) {
+	cfg := config.Default().PostFX
+	cfg.Bloom = false
+	cfg.CRT = true
+	c := New(cfg)
+
+	if c.Enabled(Bloom) || !c.Enabled(CRT) || !c.Enabled(Vignette) {
+		t.Error("Expected bloom off and the CRT and vignette on, as configured")
+	}
+	c.Toggle(Bloom)
+	c.SetEnabled(CRT, false)
+	if !c.Enabled(Bloom) || c.Enabled(CRT) {
+		t.Error("Expected the toggles to switch effects")
+	}
+
+	c.Toggle("sepia")
+	if c.Enabled("sepia") {
+		t.Error("Expected unknown effects to be ignored")
+	}
+}
+
+func TestHitEffectsFade(t *testing.T) {
+	cfg := config.Default().PostFX
+	c := New(cfg)
+	aberration, flash := c.pass(Aberration), c.pass(Flash)
+	if c.active(aberration) || c.active(flash) {
+		t.Fatal("Expected no hit effects before a hit")
+	}
+
+	c.Hit()
+	if !c.active(aberration) || !c.active(flash) {
+		t.Fatal("Expected the hit effects after a hit")
+	}
+	c.Update(cfg.FlashTime / 2)
+	c.setUniforms(aberration)
+	if got := aberration.uniforms["Amount"]; got != float32(cfg.AberrationAmount/2) {
+		t.Errorf("Expected the aberration half faded, got %v", got)
+	}
+
+	c.Update(cfg.FlashTime)
+	if c.active(aberration) || c.active(flash) {
+		t.Error("Expected the hit effects gone after the flash time")
+	}
+}
+
+func TestNothingActiveDrawsStraightToScreen(t *testing.T) {
+	cfg := config.Default().PostFX
+	cfg.Bloom, cfg.Vignette = false, false
+	c := New(cfg)
+
+	screen := ebiten.NewImage(8, 8)
+	if c.Begin(screen) != screen {
+		t.Error("Expected the scene drawn straight to the screen with no effect active")
+	}
+	c.End(screen)
+	if c.loaded {
+		t.Error("Expected shaders left uncompiled until an effect needs them")
+	}
+}
+
+func TestFallsBackWithoutShaders(t *testing.T) {
+	c := New(config.Default().PostFX)
+	c.loaded = true
+	c.err = errors.New("no shaders here")
+
+	screen := ebiten.NewImage(8, 8)
+	c.Hit()
+	if c.Begin(screen) != screen {
+		t.Error("Expected the scene drawn straight to the screen without shaders")
+	}
+	c.End(screen) // draws the flash as an overlay
+}
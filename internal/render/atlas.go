@@ -0,0 +1,130 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// AtlasSize is the width and height of the shared atlas
+const AtlasSize = 1024
+
+// atlasPadding keeps a gap between regions so filtering never samples
+// a neighbour
+const atlasPadding = 1
+
+// whiteName is the atlas region solid shapes are drawn from. It is a 3x3
+// block of which only the centre pixel is used, so the edges can't bleed.
+const whiteName = "white"
+
+// ErrAtlasFull is returned when an image doesn't fit in what is left of
+// an atlas
+var ErrAtlasFull = errors.New("render: atlas is full")
+
+// Atlas packs many small images into one texture so they can be drawn
+// from a single source image
+type Atlas struct {
+	image   *ebiten.Image
+	packer  *packer
+	regions map[string]*ebiten.Image
+	white   *ebiten.Image
+}
+
+// NewAtlas creates an empty size by size atlas holding only the white
+// pixel used for solid shapes
+func NewAtlas(size int) *Atlas {
+	a := &Atlas{
+		image:   ebiten.NewImage(size, size),
+		packer:  newPacker(size, size),
+		regions: make(map[string]*ebiten.Image),
+	}
+
+	white := image.NewRGBA(image.Rect(0, 0, 3, 3))
+	draw.Draw(white, white.Bounds(), image.White, image.Point{}, draw.Src)
+	block, err := a.Add(whiteName, white)
+	if err != nil {
+		panic(err)
+	}
+	a.white = block.SubImage(image.Rect(1, 1, 2, 2).Add(block.Bounds().Min)).(*ebiten.Image)
+	return a
+}
+
+// Add copies img into the atlas under name and returns its region
+func (a *Atlas) Add(name string, img image.Image) (*ebiten.Image, error) {
+	if _, ok := a.regions[name]; ok {
+		return nil, fmt.Errorf("render: atlas already holds %q", name)
+	}
+
+	size := img.Bounds().Size()
+	rect, ok := a.packer.place(size.X, size.Y)
+	if !ok {
+		return nil, fmt.Errorf("%w: no room for %q (%dx%d)", ErrAtlasFull, name, size.X, size.Y)
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, size.X, size.Y))
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	region := a.image.SubImage(rect).(*ebiten.Image)
+	region.WritePixels(rgba.Pix)
+
+	a.regions[name] = region
+	return region, nil
+}
+
+// Region returns the image added under name
+func (a *Atlas) Region(name string) (*ebiten.Image, bool) {
+	region, ok := a.regions[name]
+	return region, ok
+}
+
+// White returns the atlas's single white pixel
+func (a *Atlas) White() *ebiten.Image {
+	return a.white
+}
+
+// sharedAtlas is created on first use, once a graphics context exists
+var sharedAtlas *Atlas
+
+// SharedAtlas returns the atlas every draw in the game shares
+func SharedAtlas() *Atlas {
+	if sharedAtlas == nil {
+		sharedAtlas = NewAtlas(AtlasSize)
+	}
+	return sharedAtlas
+}
+
+// packer places rectangles on shelves: left to right along the current
+// shelf, starting a new shelf below the tallest rectangle when a row
+// fills up
+type packer struct {
+	width, height int
+	x, y          int
+	shelf         int
+}
+
+func newPacker(width, height int) *packer {
+	return &packer{width: width, height: height}
+}
+
+// place reserves room for a w by h rectangle and returns where it goes
+func (p *packer) place(w, h int) (image.Rectangle, bool) {
+	if w <= 0 || h <= 0 || w > p.width || h > p.height {
+		return image.Rectangle{}, false
+	}
+
+	if p.x+w > p.width {
+		p.x = 0
+		p.y += p.shelf + atlasPadding
+		p.shelf = 0
+	}
+	if p.y+h > p.height {
+		return image.Rectangle{}, false
+	}
+
+	rect := image.Rect(p.x, p.y, p.x+w, p.y+h)
+	p.x += w + atlasPadding
+	p.shelf = max(p.shelf, h)
+	return rect, true
+}
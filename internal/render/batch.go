@@ -0,0 +1,74 @@
+package render
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// maxBatchQuads is the most quads one DrawTriangles call can take. Ebiten
+// ignores vertices past MaxVerticesCount, so a full chunk stays under it.
+const maxBatchQuads = ebiten.MaxVerticesCount / 4
+
+// Batch collects solid quads and draws them together. Its buffers are
+// kept between frames, so once they have grown a frame allocates nothing.
+type Batch struct {
+	src      *ebiten.Image
+	srcX     float32
+	srcY     float32
+	vertices []ebiten.Vertex
+	indices  []uint16
+	op       ebiten.DrawTrianglesOptions
+}
+
+// NewBatch creates a batch drawing from src, a single white pixel such
+// as the shared atlas's
+func NewBatch(src *ebiten.Image) *Batch {
+	// Every corner samples the middle of the source pixel
+	b := &Batch{src: src, srcX: 0.5, srcY: 0.5}
+	if src != nil {
+		origin := src.Bounds().Min
+		b.srcX, b.srcY = float32(origin.X)+0.5, float32(origin.Y)+0.5
+	}
+	b.op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	return b
+}
+
+// Quad adds a w by h quad with its top left corner at x, y, tinted by cs
+func (b *Batch) Quad(x, y, w, h float64, cs ebiten.ColorScale) {
+	x0, y0 := float32(x), float32(y)
+	x1, y1 := float32(x+w), float32(y+h)
+	r, g, bl, a := cs.R(), cs.G(), cs.B(), cs.A()
+	sx, sy := b.srcX, b.srcY
+
+	b.vertices = append(b.vertices,
+		ebiten.Vertex{DstX: x0, DstY: y0, SrcX: sx, SrcY: sy, ColorR: r, ColorG: g, ColorB: bl, ColorA: a},
+		ebiten.Vertex{DstX: x1, DstY: y0, SrcX: sx, SrcY: sy, ColorR: r, ColorG: g, ColorB: bl, ColorA: a},
+		ebiten.Vertex{DstX: x0, DstY: y1, SrcX: sx, SrcY: sy, ColorR: r, ColorG: g, ColorB: bl, ColorA: a},
+		ebiten.Vertex{DstX: x1, DstY: y1, SrcX: sx, SrcY: sy, ColorR: r, ColorG: g, ColorB: bl, ColorA: a},
+	)
+}
+
+// Len returns the number of quads waiting to be drawn
+func (b *Batch) Len() int {
+	return len(b.vertices) / 4
+}
+
+// Flush draws every quad added since the last flush onto dst, in as few
+// DrawTriangles calls as the index size allows, and empties the batch
+func (b *Batch) Flush(dst Target) {
+	if len(b.vertices) == 0 {
+		return
+	}
+
+	// Quads use the same indices in every call, so they are built once
+	quads := b.Len()
+	for n := len(b.indices) / 6; n < min(quads, maxBatchQuads); n++ {
+		v := uint16(n * 4)
+		b.indices = append(b.indices, v, v+1, v+2, v+1, v+3, v+2)
+	}
+
+	for start := 0; start < quads; start += maxBatchQuads {
+		end := min(start+maxBatchQuads, quads)
+		dst.DrawTriangles(b.vertices[start*4:end*4], b.indices[:(end-start)*6], b.src, &b.op)
+	}
+	b.vertices = b.vertices[:0]
+}
@@ -0,0 +1,172 @@
+package render
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Layer is a band of the scene. Layers are drawn back to front in the
+// order listed here.
+type Layer int
+
+const (
+	LayerBackground Layer = iota // the backdrop: stars and anything behind them
+	LayerParallax                // scenery scrolling between backdrop and play
+	LayerGameplay                // ships, bullets and their effects
+	LayerEffects                 // effects over the play, such as score popups
+	LayerUI                      // the HUD, menus and debug text
+	layerCount
+)
+
+// Layers lists every layer, back to front
+var Layers = []Layer{LayerBackground, LayerParallax, LayerGameplay, LayerEffects, LayerUI}
+
+// String returns the layer's name
+func (l Layer) String() string {
+	switch l {
+	case LayerBackground:
+		return "background"
+	case LayerParallax:
+		return "parallax"
+	case LayerGameplay:
+		return "gameplay"
+	case LayerEffects:
+		return "effects"
+	case LayerUI:
+		return "ui"
+	}
+	return "unknown"
+}
+
+// Drawable is anything that can draw itself onto the screen. Entities
+// and the UI's pieces satisfy it.
+type Drawable interface {
+	Draw(screen *ebiten.Image)
+}
+
+// DrawFunc adapts a function to a Drawable
+type DrawFunc func(screen *ebiten.Image)
+
+// Draw calls f
+func (f DrawFunc) Draw(screen *ebiten.Image) {
+	f(screen)
+}
+
+// Source puts drawables into a queue each frame
+type Source interface {
+	Submit(q *Queue)
+}
+
+// SourceFunc adapts a function to a Source
+type SourceFunc func(q *Queue)
+
+// Submit calls f
+func (f SourceFunc) Submit(q *Queue) {
+	f(q)
+}
+
+// At returns a source that queues d in layer at depth z every frame
+func At(layer Layer, z float64, d Drawable) Source {
+	return SourceFunc(func(q *Queue) {
+		q.Submit(layer, z, d)
+	})
+}
+
+// queued is a drawable waiting in a layer, with the order it arrived in
+// to keep drawables at the same z in submission order
+type queued struct {
+	z     float64
+	order int
+	d     Drawable
+}
+
+// Queue collects a frame's drawables and draws them by layer, then by z
+// within a layer, lowest first. Its buffers are kept between frames, so
+// once they have grown queuing allocates nothing.
+type Queue struct {
+	layers [layerCount][]queued
+	hidden [layerCount]bool
+	order  int
+}
+
+// NewQueue creates an empty queue with every layer shown
+func NewQueue() *Queue {
+	return &Queue{}
+}
+
+// Submit queues d to be drawn in layer at depth z. Drawables for hidden
+// layers are dropped.
+func (q *Queue) Submit(layer Layer, z float64, d Drawable) {
+	if layer < 0 || layer >= layerCount || q.hidden[layer] {
+		return
+	}
+	q.layers[layer] = append(q.layers[layer], queued{z: z, order: q.order, d: d})
+	q.order++
+}
+
+// Len returns the number of drawables waiting in layer
+func (q *Queue) Len(layer Layer) int {
+	return len(q.layers[layer])
+}
+
+// Draw draws everything queued onto dst and empties the queue
+func (q *Queue) Draw(dst *ebiten.Image) {
+	q.DrawLayers(dst, LayerBackground, LayerUI)
+}
+
+// DrawLayers draws what is queued in the layers from first to last onto
+// dst and empties them, leaving the other layers queued
+func (q *Queue) DrawLayers(dst *ebiten.Image, first, last Layer) {
+	for l := max(first, 0); l <= min(last, layerCount-1); l++ {
+		items := q.layers[l]
+		slices.SortFunc(items, compareQueued)
+		for i := range items {
+			items[i].d.Draw(dst)
+			items[i].d = nil
+		}
+		q.layers[l] = items[:0]
+	}
+
+	for l := range q.layers {
+		if len(q.layers[l]) > 0 {
+			return
+		}
+	}
+	q.order = 0
+}
+
+// Reset drops everything queued without drawing it
+func (q *Queue) Reset() {
+	for l := range q.layers {
+		clear(q.layers[l])
+		q.layers[l] = q.layers[l][:0]
+	}
+	q.order = 0
+}
+
+// compareQueued orders drawables by z, then by when they were submitted
+func compareQueued(a, b queued) int {
+	if c := cmp.Compare(a.z, b.z); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.order, b.order)
+}
+
+// SetHidden hides or shows a layer from the next frame
+func (q *Queue) SetHidden(layer Layer, hidden bool) {
+	if layer >= 0 && layer < layerCount {
+		q.hidden[layer] = hidden
+	}
+}
+
+// Hidden reports whether a layer is hidden
+func (q *Queue) Hidden(layer Layer) bool {
+	return layer >= 0 && layer < layerCount && q.hidden[layer]
+}
+
+// Toggle hides a shown layer or shows a hidden one
+func (q *Queue) Toggle(layer Layer) {
+	q.SetHidden(layer, !q.Hidden(layer))
+}
@@ -0,0 +1,128 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// recorder is a drawable that notes when it was drawn
+type recorder struct {
+	name string
+	log  *[]string
+}
+
+func (r *recorder) Draw(screen *ebiten.Image) {
+	*r.log = append(*r.log, r.name)
+}
+
+func TestQueueDrawsByLayerThenDepth(t *testing.T) {
+	var log []string
+	d := func(name string) Drawable { return &recorder{name: name, log: &log} }
+
+	q := NewQueue()
+	q.Submit(LayerUI, 0, d("hud"))
+	q.Submit(LayerGameplay, 5, d("player"))
+	q.Submit(LayerGameplay, 1, d("bullet a"))
+	q.Submit(LayerBackground, 9, d("stars"))
+	q.Submit(LayerGameplay, 1, d("bullet b"))
+	q.Submit(LayerEffects, -1, d("popup"))
+	q.Draw(nil)
+
+	want := []string{"stars", "bullet a", "bullet b", "player", "popup", "hud"}
+	if len(log) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, log)
+	}
+	for i := range want {
+		if log[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, log)
+		}
+	}
+
+	// The queue starts empty again for the next frame
+	log = log[:0]
+	q.Draw(nil)
+	if len(log) != 0 || q.Len(LayerGameplay) != 0 {
+		t.Errorf("Expected an empty queue after drawing, got %v", log)
+	}
+}
+
+func TestQueueDrawsSomeLayers(t *testing.T) {
+	var log []string
+	d := func(name string) Drawable { return &recorder{name: name, log: &log} }
+
+	q := NewQueue()
+	q.Submit(LayerUI, 0, d("hud"))
+	q.Submit(LayerBackground, 0, d("stars"))
+	q.Submit(LayerEffects, 0, d("popup"))
+
+	q.DrawLayers(nil, LayerBackground, LayerEffects)
+	if len(log) != 2 || log[1] != "popup" || q.Len(LayerUI) != 1 {
+		t.Fatalf("Expected the play drawn and the HUD left queued, got %v", log)
+	}
+	q.DrawLayers(nil, LayerUI, LayerUI)
+	if len(log) != 3 || log[2] != "hud" {
+		t.Errorf("Expected the HUD drawn last, got %v", log)
+	}
+}
+
+func TestQueueHidesLayers(t *testing.T) {
+	var log []string
+	q := NewQueue()
+
+	q.Toggle(LayerGameplay)
+	if !q.Hidden(LayerGameplay) || q.Hidden(LayerUI) {
+		t.Fatal("Expected only the gameplay layer hidden")
+	}
+	q.Submit(LayerGameplay, 0, &recorder{name: "enemy", log: &log})
+	q.Submit(LayerUI, 0, &recorder{name: "hud", log: &log})
+	q.Draw(nil)
+	if len(log) != 1 || log[0] != "hud" {
+		t.Errorf("Expected just the HUD, got %v", log)
+	}
+
+	q.Toggle(LayerGameplay)
+	q.Submit(LayerGameplay, 0, &recorder{name: "enemy", log: &log})
+	if q.Len(LayerGameplay) != 1 {
+		t.Error("Expected the layer to be drawn again once shown")
+	}
+	q.Reset()
+	if q.Len(LayerGameplay) != 0 {
+		t.Error("Expected Reset to drop what was queued")
+	}
+
+	// Layers that don't exist are ignored
+	q.Submit(Layer(99), 0, &recorder{name: "stray", log: &log})
+	q.SetHidden(Layer(-1), true)
+}
+
+// nothing is a drawable that draws nothing
+type nothing struct{}
+
+func (nothing) Draw(screen *ebiten.Image) {}
+
+func TestQueueDoesNotAllocate(t *testing.T) {
+	q := NewQueue()
+	frame := func() {
+		for i := 0; i < 200; i++ {
+			q.Submit(Layers[i%len(Layers)], float64(200-i), nothing{})
+		}
+		q.Draw(nil)
+	}
+	frame() // grows the layer buffers
+
+	if allocs := testing.AllocsPerRun(10, frame); allocs != 0 {
+		t.Errorf("Expected no allocations per frame, got %v", allocs)
+	}
+}
+
+func TestLayerNames(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, l := range Layers {
+		name := l.String()
+		if name == "unknown" || seen[name] {
+			t.Errorf("Expected a unique name for layer %d, got %q", l, name)
+		}
+		seen[name] = true
+	}
+}
@@ -0,0 +1,106 @@
+// Package render draws the game's shapes without creating images each
+// frame. Solid shapes are one white pixel from a shared texture atlas,
+// scaled into place and tinted with a ColorScale, and large numbers of
+// small quads such as particles and stars go out in a single batch.
+package render
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Target is what shapes are drawn onto. *ebiten.Image satisfies it.
+type Target interface {
+	DrawImage(img *ebiten.Image, options *ebiten.DrawImageOptions)
+	DrawTriangles(vertices []ebiten.Vertex, indices []uint16, img *ebiten.Image, options *ebiten.DrawTrianglesOptions)
+}
+
+// Painter draws solid shapes from a white source image, reusing its
+// draw options so a shape costs no allocations
+type Painter struct {
+	white *ebiten.Image
+	op    ebiten.DrawImageOptions
+}
+
+// NewPainter creates a painter drawing from white, a single white pixel
+func NewPainter(white *ebiten.Image) *Painter {
+	return &Painter{white: white}
+}
+
+// FillRect draws a solid rectangle with its top left corner at x, y
+func (p *Painter) FillRect(dst Target, x, y, w, h float64, clr color.Color) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	p.op.GeoM.Reset()
+	p.op.GeoM.Scale(w, h)
+	p.op.GeoM.Translate(x, y)
+	p.draw(dst, clr)
+}
+
+// FillRectRotated draws a solid rectangle centred on x, y and turned by
+// angle radians
+func (p *Painter) FillRectRotated(dst Target, x, y, w, h, angle float64, clr color.Color) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	p.op.GeoM.Reset()
+	p.op.GeoM.Scale(w, h)
+	p.op.GeoM.Translate(-w/2, -h/2)
+	p.op.GeoM.Rotate(angle)
+	p.op.GeoM.Translate(x, y)
+	p.draw(dst, clr)
+}
+
+// FillRectScale draws a rectangle like FillRect, tinted by a color scale
+func (p *Painter) FillRectScale(dst Target, x, y, w, h float64, cs ebiten.ColorScale) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	p.op.GeoM.Reset()
+	p.op.GeoM.Scale(w, h)
+	p.op.GeoM.Translate(x, y)
+	p.op.ColorScale = cs
+	dst.DrawImage(p.white, &p.op)
+}
+
+func (p *Painter) draw(dst Target, clr color.Color) {
+	p.op.ColorScale.Reset()
+	p.op.ColorScale.ScaleWithColor(clr)
+	dst.DrawImage(p.white, &p.op)
+}
+
+// Scale returns the color scale that tints white to clr
+func Scale(clr color.Color) ebiten.ColorScale {
+	var cs ebiten.ColorScale
+	cs.ScaleWithColor(clr)
+	return cs
+}
+
+// shared is the painter behind the package-level drawing functions,
+// created with the shared atlas on first use
+var shared *Painter
+
+// Default returns the painter the package-level functions draw with
+func Default() *Painter {
+	if shared == nil {
+		shared = NewPainter(SharedAtlas().White())
+	}
+	return shared
+}
+
+// FillRect draws a solid rectangle with the default painter
+func FillRect(dst Target, x, y, w, h float64, clr color.Color) {
+	Default().FillRect(dst, x, y, w, h, clr)
+}
+
+// FillRectScale draws a tinted rectangle with the default painter
+func FillRectScale(dst Target, x, y, w, h float64, cs ebiten.ColorScale) {
+	Default().FillRectScale(dst, x, y, w, h, cs)
+}
+
+// FillRectRotated draws a turned rectangle with the default painter
+func FillRectRotated(dst Target, x, y, w, h, angle float64, clr color.Color) {
+	Default().FillRectRotated(dst, x, y, w, h, angle, clr)
+}
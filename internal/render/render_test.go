@@ -0,0 +1,187 @@
+package render
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// countingTarget records draw calls without touching the GPU. Like
+// ebiten, it drops vertices past MaxVerticesCount and panics on indices
+// that don't reach a vertex.
+type countingTarget struct {
+	images    int
+	triangles int
+	vertices  int
+	indices   int
+	lastScale ebiten.ColorScale
+}
+
+func (c *countingTarget) DrawImage(img *ebiten.Image, op *ebiten.DrawImageOptions) {
+	c.images++
+	c.lastScale = op.ColorScale
+}
+
+func (c *countingTarget) DrawTriangles(vertices []ebiten.Vertex, indices []uint16, img *ebiten.Image, op *ebiten.DrawTrianglesOptions) {
+	c.triangles++
+	if len(vertices) > ebiten.MaxVerticesCount {
+		vertices = vertices[:ebiten.MaxVerticesCount]
+	}
+	c.vertices += len(vertices)
+	c.indices += len(indices)
+	for _, i := range indices {
+		if int(i) >= len(vertices) {
+			panic("index past the end of the vertices")
+		}
+	}
+}
+
+func TestFillRectSkipsEmptyShapes(t *testing.T) {
+	p := NewPainter(nil)
+	dst := &countingTarget{}
+
+	p.FillRect(dst, 0, 0, 0, 10, color.White)
+	p.FillRectRotated(dst, 0, 0, 10, -1, 1, color.White)
+	if dst.images != 0 {
+		t.Errorf("Expected empty shapes to draw nothing, got %d draws", dst.images)
+	}
+
+	p.FillRect(dst, 0, 0, 4, 4, color.RGBA{R: 255, A: 255})
+	if dst.images != 1 || dst.lastScale.R() != 1 || dst.lastScale.G() != 0 {
+		t.Errorf("Expected one red draw, got %d with scale %v", dst.images, dst.lastScale)
+	}
+}
+
+func TestBatchSplitsLargeFlushes(t *testing.T) {
+	b := NewBatch(nil)
+	dst := &countingTarget{}
+
+	quads := maxBatchQuads*2 + 10
+	for i := 0; i < quads; i++ {
+		b.Quad(float64(i), 0, 1, 1, Scale(color.White))
+	}
+	if b.Len() != quads {
+		t.Fatalf("Expected %d quads, got %d", quads, b.Len())
+	}
+	b.Flush(dst)
+
+	if dst.triangles != 3 {
+		t.Errorf("Expected 3 draw calls, got %d", dst.triangles)
+	}
+	if dst.vertices != quads*4 || dst.indices != quads*6 {
+		t.Errorf("Expected %d vertices and %d indices, got %d and %d", quads*4, quads*6, dst.vertices, dst.indices)
+	}
+	if b.Len() != 0 {
+		t.Error("Expected the batch to be empty after a flush")
+	}
+
+	b.Flush(dst)
+	if dst.triangles != 3 {
+		t.Error("Expected an empty flush to draw nothing")
+	}
+}
+
+func TestPackerPlacesOnShelves(t *testing.T) {
+	p := newPacker(10, 10)
+
+	a, _ := p.place(4, 3)
+	b, _ := p.place(4, 5)
+	c, ok := p.place(4, 2) // doesn't fit beside b, so starts a shelf
+	if !ok {
+		t.Fatal("Expected room for a third rectangle")
+	}
+	if a.Overlaps(b) || a.Overlaps(c) || b.Overlaps(c) {
+		t.Errorf("Expected no overlap, got %v %v %v", a, b, c)
+	}
+	if c.Min != (image.Point{0, 5 + atlasPadding}) {
+		t.Errorf("Expected a new shelf below the tallest rectangle, got %v", c)
+	}
+
+	if _, ok := p.place(11, 1); ok {
+		t.Error("Expected a rectangle wider than the atlas to be refused")
+	}
+	if _, ok := p.place(4, 5); ok {
+		t.Error("Expected no room once the atlas is full")
+	}
+}
+
+func TestAtlasAdd(t *testing.T) {
+	atlas := NewAtlas(16)
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	region, err := atlas.Add("ship", img)
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if region.Bounds().Dx() != 4 || region.Bounds().Dy() != 4 {
+		t.Errorf("Expected a 4x4 region, got %v", region.Bounds())
+	}
+	if got, ok := atlas.Region("ship"); !ok || got != region {
+		t.Error("Expected to look the region up by name")
+	}
+	if _, err := atlas.Add("ship", img); err == nil {
+		t.Error("Expected a duplicate name to be refused")
+	}
+	if _, err := atlas.Add("huge", image.NewRGBA(image.Rect(0, 0, 32, 32))); !errors.Is(err, ErrAtlasFull) {
+		t.Errorf("Expected ErrAtlasFull, got %v", err)
+	}
+	if atlas.White().Bounds().Dx() != 1 {
+		t.Errorf("Expected a single white pixel, got %v", atlas.White().Bounds())
+	}
+}
+
+// Entities keep their colors in a color.Color, so the frame below draws
+// with colors that are already boxed, as the game does
+var (
+	shipColor   color.Color = color.RGBA{R: 255, A: 255}
+	bulletColor color.Color = color.RGBA{R: 255, G: 255, A: 255}
+	sparkColor  color.Color = color.RGBA{R: 200, G: 120, A: 255}
+)
+
+// drawFrame draws what a busy frame draws: solid shapes for the entities
+// and a batch of particles and stars
+func drawFrame(p *Painter, b *Batch, dst Target) {
+	for i := 0; i < 50; i++ {
+		p.FillRect(dst, float64(i), 10, 20, 20, shipColor)
+		p.FillRectRotated(dst, float64(i), 40, 4, 12, 0.5, bulletColor)
+	}
+	for i := 0; i < 500; i++ {
+		cs := Scale(sparkColor)
+		cs.ScaleAlpha(0.5)
+		b.Quad(float64(i), 80, 3, 3, cs)
+	}
+	b.Flush(dst)
+}
+
+func TestFrameDoesNotAllocate(t *testing.T) {
+	p, b := NewPainter(nil), NewBatch(nil)
+	dst := &countingTarget{}
+	drawFrame(p, b, dst) // grows the batch buffers
+
+	if allocs := testing.AllocsPerRun(10, func() { drawFrame(p, b, dst) }); allocs != 0 {
+		t.Errorf("Expected no allocations per frame, got %v", allocs)
+	}
+}
+
+func BenchmarkFillRect(b *testing.B) {
+	p := NewPainter(nil)
+	dst := &countingTarget{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p.FillRect(dst, 10, 10, 20, 20, shipColor)
+	}
+}
+
+func BenchmarkBatchFrame(b *testing.B) {
+	p, batch := NewPainter(nil), NewBatch(nil)
+	dst := &countingTarget{}
+	drawFrame(p, batch, dst)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		drawFrame(p, batch, dst)
+	}
+}
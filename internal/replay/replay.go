@@ -0,0 +1,299 @@
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/input"
+	"github.com/EchoSingh/space-shooter/internal/level"
+	"gopkg.in/yaml.v3"
+)
+
+// File layout, all integers little endian or varint encoded:
+//
+//	magic    "SSRP"
+//	version  uint16
+//	seed     int64
+//	setup    uint32 config hash, uint32 level hash (from version 3)
+//	scores   uvarint count, then a varint per score (from version 2)
+//	ticks    uvarint, number of recorded ticks
+//	runs     uvarint run length, uvarint action set, repeated until ticks are covered
+//	score    varint
+//	tick     uvarint
+//	health   varint
+//	crc      uint32, CRC-32 (IEEE) of everything before it
+const (
+	Magic   = "SSRP"
+	Version = 3
+)
+
+var (
+	// ErrBadMagic is returned when a file is not a replay
+	ErrBadMagic = errors.New("replay: not a replay file")
+	// ErrChecksum is returned when a replay file is corrupted
+	ErrChecksum = errors.New("replay: file checksum mismatch")
+	// ErrDesync is returned when playback ends in a different state than recorded
+	ErrDesync = errors.New("replay: desync")
+	// ErrSetupMismatch is returned when a replay is played back with a
+	// different config or level than it was recorded with
+	ErrSetupMismatch = errors.New("replay: setup mismatch")
+)
+
+// State is the end-of-run state used to detect desyncs
+type State struct {
+	Score  int
+	Tick   uint64
+	Health int
+}
+
+// Setup fingerprints the config and level a run was recorded with. Level
+// is zero in endless mode. Recordings older than version 3 have a zero
+// Setup and can't be checked.
+type Setup struct {
+	Config uint32
+	Level  uint32
+}
+
+// SetupOf fingerprints cfg and lvl, which is nil in endless mode
+func SetupOf(cfg *config.Config, lvl *level.Level) Setup {
+	s := Setup{Config: fingerprint(cfg)}
+	if lvl != nil {
+		s.Level = fingerprint(lvl)
+	}
+	return s
+}
+
+// fingerprint hashes v's YAML encoding, which covers every setting
+// whether it came from a file or a default
+func fingerprint(v any) uint32 {
+	data, _ := yaml.Marshal(v)
+	return crc32.ChecksumIEEE(data)
+}
+
+// Replay is a recorded run: the seed plus one action set per tick.
+// HighScores holds the scores in the high-score table when recording
+// began, so playback sends the same runs to name entry.
+type Replay struct {
+	Seed       int64
+	Setup      Setup
+	HighScores []int
+	Actions    []input.Action
+	Final      State
+}
+
+// Verify checks that a playback ended in the recorded state
+func (r *Replay) Verify(final State) error {
+	if final != r.Final {
+		return fmt.Errorf("%w: recorded %+v, got %+v", ErrDesync, r.Final, final)
+	}
+	return nil
+}
+
+// CheckSetup checks that a playback uses the config and level the run was
+// recorded with, so a mismatch is caught before playing rather than
+// showing up as a desync at the end
+func (r *Replay) CheckSetup(s Setup) error {
+	if r.Setup == (Setup{}) || s == r.Setup {
+		return nil
+	}
+	switch {
+	case s.Config != r.Setup.Config:
+		return fmt.Errorf("%w: recorded with a different config", ErrSetupMismatch)
+	case r.Setup.Level == 0:
+		return fmt.Errorf("%w: recorded in endless mode, without a level", ErrSetupMismatch)
+	case s.Level == 0:
+		return fmt.Errorf("%w: recorded with a level", ErrSetupMismatch)
+	}
+	return fmt.Errorf("%w: recorded with a different level", ErrSetupMismatch)
+}
+
+// Write encodes a replay
+func Write(w io.Writer, r *Replay) error {
+	var buf bytes.Buffer
+	buf.WriteString(Magic)
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(Version))
+	_ = binary.Write(&buf, binary.LittleEndian, r.Seed)
+	_ = binary.Write(&buf, binary.LittleEndian, r.Setup.Config)
+	_ = binary.Write(&buf, binary.LittleEndian, r.Setup.Level)
+
+	putUvarint(&buf, uint64(len(r.HighScores)))
+	for _, score := range r.HighScores {
+		putVarint(&buf, int64(score))
+	}
+
+	putUvarint(&buf, uint64(len(r.Actions)))
+	for i := 0; i < len(r.Actions); {
+		run := 1
+		for i+run < len(r.Actions) && r.Actions[i+run] == r.Actions[i] {
+			run++
+		}
+		putUvarint(&buf, uint64(run))
+		putUvarint(&buf, uint64(r.Actions[i]))
+		i += run
+	}
+
+	putVarint(&buf, int64(r.Final.Score))
+	putUvarint(&buf, r.Final.Tick)
+	putVarint(&buf, int64(r.Final.Health))
+
+	_ = binary.Write(&buf, binary.LittleEndian, crc32.ChecksumIEEE(buf.Bytes()))
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Read decodes a replay
+func Read(r io.Reader) (*Replay, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < len(Magic)+4 || string(data[:len(Magic)]) != Magic {
+		return nil, ErrBadMagic
+	}
+
+	body, sum := data[:len(data)-4], binary.LittleEndian.Uint32(data[len(data)-4:])
+	if crc32.ChecksumIEEE(body) != sum {
+		return nil, ErrChecksum
+	}
+
+	br := bytes.NewReader(body[len(Magic):])
+	var version uint16
+	if err := binary.Read(br, binary.LittleEndian, &version); err != nil {
+		return nil, truncated(err)
+	}
+	if version < 1 || version > Version {
+		return nil, fmt.Errorf("replay: unsupported version %d", version)
+	}
+
+	rep := &Replay{}
+	if err := binary.Read(br, binary.LittleEndian, &rep.Seed); err != nil {
+		return nil, truncated(err)
+	}
+
+	// Earlier versions kept no setup
+	if version >= 3 {
+		if err := binary.Read(br, binary.LittleEndian, &rep.Setup); err != nil {
+			return nil, truncated(err)
+		}
+	}
+
+	// Version 1 recordings kept no high scores
+	if version >= 2 {
+		count, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, truncated(err)
+		}
+		if count > uint64(len(body)) {
+			return nil, fmt.Errorf("replay: implausible high-score count %d", count)
+		}
+		for i := uint64(0); i < count; i++ {
+			score, err := binary.ReadVarint(br)
+			if err != nil {
+				return nil, truncated(err)
+			}
+			rep.HighScores = append(rep.HighScores, int(score))
+		}
+	}
+
+	ticks, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, truncated(err)
+	}
+	if ticks > uint64(len(body))*(1<<16) {
+		return nil, fmt.Errorf("replay: implausible tick count %d", ticks)
+	}
+	rep.Actions = make([]input.Action, 0, ticks)
+	for uint64(len(rep.Actions)) < ticks {
+		run, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, truncated(err)
+		}
+		action, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, truncated(err)
+		}
+		if run == 0 || uint64(len(rep.Actions))+run > ticks {
+			return nil, fmt.Errorf("replay: bad run length %d", run)
+		}
+		for i := uint64(0); i < run; i++ {
+			rep.Actions = append(rep.Actions, input.Action(action))
+		}
+	}
+
+	score, err := binary.ReadVarint(br)
+	if err != nil {
+		return nil, truncated(err)
+	}
+	tick, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, truncated(err)
+	}
+	health, err := binary.ReadVarint(br)
+	if err != nil {
+		return nil, truncated(err)
+	}
+	rep.Final = State{Score: int(score), Tick: tick, Health: int(health)}
+
+	return rep, nil
+}
+
+// Save writes a replay to a file
+func Save(path string, r *Replay) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	if err := Write(w, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load reads a replay from a file
+func Load(path string) (*Replay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rep, err := Read(bufio.NewReader(f))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rep, nil
+}
+
+func truncated(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return errors.New("replay: file is truncated")
+	}
+	return err
+}
+
+func putUvarint(buf *bytes.Buffer, v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	buf.Write(tmp[:n])
+}
+
+func putVarint(buf *bytes.Buffer, v int64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(tmp[:], v)
+	buf.Write(tmp[:n])
+}
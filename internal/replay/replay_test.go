@@ -0,0 +1,289 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/internal/engine"
+	"github.com/EchoSingh/space-shooter/internal/game"
+	"github.com/EchoSingh/space-shooter/internal/highscore"
+	"github.com/EchoSingh/space-shooter/internal/input"
+	"github.com/EchoSingh/space-shooter/internal/level"
+)
+
+func sampleReplay() *Replay {
+	actions := []input.Action{input.None, input.ActionConfirm}
+	for i := 0; i < 100; i++ {
+		actions = append(actions, input.ActionFire|input.ActionLeft)
+	}
+	actions = append(actions, input.ActionUp, input.None)
+
+	return &Replay{
+		Seed:       -12345,
+		Setup:      Setup{Config: 0xc0ffee, Level: 42},
+		HighScores: []int{900, 450, 120},
+		Actions:    actions,
+		Final:      State{Score: 120, Tick: uint64(len(actions)), Health: 80},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	want := sampleReplay()
+
+	var buf bytes.Buffer
+	if err := Write(&buf, want); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+
+	if got.Seed != want.Seed || got.Setup != want.Setup || got.Final != want.Final || !slices.Equal(got.HighScores, want.HighScores) {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+	if len(got.Actions) != len(want.Actions) {
+		t.Fatalf("Expected %d actions, got %d", len(want.Actions), len(got.Actions))
+	}
+	for i := range want.Actions {
+		if got.Actions[i] != want.Actions[i] {
+			t.Fatalf("Action %d: expected %v, got %v", i, want.Actions[i], got.Actions[i])
+		}
+	}
+}
+
+func TestRunLengthEncodingIsCompact(t *testing.T) {
+	rep := &Replay{Seed: 1}
+	for i := 0; i < 10000; i++ {
+		rep.Actions = append(rep.Actions, input.ActionFire)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, rep); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if buf.Len() > 64 {
+		t.Errorf("Expected a held action to encode compactly, got %d bytes", buf.Len())
+	}
+}
+
+func TestReadRejectsCorruptFiles(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, sampleReplay()); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data := buf.Bytes()
+
+	if _, err := Read(bytes.NewReader([]byte("nope"))); !errors.Is(err, ErrBadMagic) {
+		t.Errorf("Expected ErrBadMagic, got %v", err)
+	}
+
+	flipped := append([]byte(nil), data...)
+	flipped[len(Magic)+8] ^= 0xff
+	if _, err := Read(bytes.NewReader(flipped)); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Expected ErrChecksum, got %v", err)
+	}
+
+	if _, err := Read(bytes.NewReader(data[:len(data)-6])); err == nil {
+		t.Error("Expected an error for a truncated file")
+	}
+}
+
+func TestReadVersion1(t *testing.T) {
+	want := sampleReplay()
+	want.Setup = Setup{}
+	want.HighScores = nil
+	var buf bytes.Buffer
+	if err := Write(&buf, want); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	// Version 1 had no setup or high scores between the seed and the ticks
+	data := buf.Bytes()
+	seedEnd := len(Magic) + 2 + 8
+	old := append([]byte(nil), data[:seedEnd]...)
+	old = append(old, data[seedEnd+8+1:len(data)-4]...)
+	binary.LittleEndian.PutUint16(old[len(Magic):], 1)
+	old = binary.LittleEndian.AppendUint32(old, crc32.ChecksumIEEE(old))
+
+	got, err := Read(bytes.NewReader(old))
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if got.Final != want.Final || len(got.Actions) != len(want.Actions) || got.HighScores != nil {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
+
+func TestCheckSetup(t *testing.T) {
+	lvl, err := level.Parse([]byte(`
+waves:
+  - enemies:
+      - type: "basic"
+        count: 3
+`))
+	if err != nil {
+		t.Fatalf("level.Parse failed: %v", err)
+	}
+	cfg := config.Default()
+	rep := &Replay{Setup: SetupOf(cfg, lvl)}
+
+	if err := rep.CheckSetup(SetupOf(config.Default(), lvl)); err != nil {
+		t.Errorf("Expected the same config and level to match, got %v", err)
+	}
+
+	tweaked := config.Default()
+	tweaked.Player.Speed++
+	harder := *lvl
+	harder.Waves = append([]level.Wave(nil), lvl.Waves...)
+	harder.Waves[0].Groups = []level.Group{{Type: "tank", Count: 3}}
+	for name, setup := range map[string]Setup{
+		"config":   SetupOf(tweaked, lvl),
+		"level":    SetupOf(cfg, &harder),
+		"no level": SetupOf(cfg, nil),
+		"both":     SetupOf(tweaked, nil),
+	} {
+		if err := rep.CheckSetup(setup); !errors.Is(err, ErrSetupMismatch) {
+			t.Errorf("%s: expected ErrSetupMismatch, got %v", name, err)
+		}
+	}
+
+	if err := (&Replay{}).CheckSetup(SetupOf(cfg, nil)); err != nil {
+		t.Errorf("Expected a replay without a setup to play anywhere, got %v", err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	rep := sampleReplay()
+
+	if err := rep.Verify(rep.Final); err != nil {
+		t.Errorf("Expected matching state to verify, got %v", err)
+	}
+
+	bad := rep.Final
+	bad.Score++
+	if err := rep.Verify(bad); !errors.Is(err, ErrDesync) {
+		t.Errorf("Expected ErrDesync, got %v", err)
+	}
+}
+
+func TestRecordAndPlayBackGame(t *testing.T) {
+	cfg := config.Default()
+	script := input.NewScript(input.None, input.ActionConfirm).
+		Hold(input.ActionFire|input.ActionLeft, 300).
+		Hold(input.ActionFire|input.ActionRight, 600)
+
+	recorder := NewRecorder(script, 99)
+	recorder.SetSetup(SetupOf(cfg, nil))
+	g, err := game.NewGame(cfg, recorder, 99)
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+	for !script.Done() {
+		_ = g.Update()
+	}
+	rep := recorder.Finish(stateOf(g))
+
+	path := filepath.Join(t.TempDir(), "run.rep")
+	if err := Save(path, rep); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if err := loaded.CheckSetup(SetupOf(cfg, nil)); err != nil {
+		t.Fatalf("Expected the recorded setup, got %v", err)
+	}
+
+	playback := NewPlayback(loaded)
+	replayed, err := game.NewGame(cfg, playback, loaded.Seed)
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+	for !playback.Done() {
+		_ = replayed.Update()
+	}
+
+	if err := loaded.Verify(stateOf(replayed)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRecordAndPlayBackPastGameOver(t *testing.T) {
+	cfg := config.Default()
+	cfg.Player.Lives = 1
+	cfg.Game.HighScores = 1
+
+	// Start, die with a qualifying score, save the name and go back to
+	// the menu. Each press is let go for a tick so the next one registers.
+	script := input.NewScript(input.None, input.ActionConfirm).
+		Hold(input.None, 20).
+		Hold(input.ActionConfirm, 1).Hold(input.None, 1).
+		Hold(input.ActionConfirm, 1).Hold(input.None, 1)
+
+	// The run ends the same way at the same tick in both games
+	play := func(g *game.Game, done func() bool) {
+		for tick := 0; !done(); tick++ {
+			if tick == 10 {
+				g.Player().AddScore(150)
+				g.Player().Health.Damage(g.Player().Health.Maximum)
+			}
+			_ = g.Update()
+		}
+	}
+
+	table := highscore.New(cfg.Game.HighScores)
+	recorder := NewRecorder(script, 7)
+	recorder.SetHighScores(table.Scores())
+	g, err := game.NewGame(cfg, recorder, 7)
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+	g.SetHighScores(table, "")
+	play(g, script.Done)
+	if len(table.Entries) != 1 || g.State() != engine.StateMenu {
+		t.Fatalf("Expected the run saved and the menu shown, got %+v in %v", table.Entries, g.State())
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, recorder.Finish(stateOf(g))); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	loaded, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+
+	// The table now holds the run, which would no longer qualify
+	playback := NewPlayback(loaded)
+	replayed, err := game.NewGame(cfg, playback, loaded.Seed)
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+	replayed.SetHighScores(highscore.FromScores(cfg.Game.HighScores, loaded.HighScores), "")
+	play(replayed, playback.Done)
+
+	if err := loaded.Verify(stateOf(replayed)); err != nil {
+		t.Error(err)
+	}
+	if replayed.State() != g.State() {
+		t.Errorf("Expected playback to end in %v, got %v", g.State(), replayed.State())
+	}
+}
+
+func stateOf(g *game.Game) State {
+	state := State{Tick: g.Tick()}
+	if p := g.Player(); p != nil {
+		state.Score = p.GetScore()
+		state.Health = p.Health.Current
+	}
+	return state
+}
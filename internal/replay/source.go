@@ -0,0 +1,69 @@
+package replay
+
+import "github.com/EchoSingh/space-shooter/internal/input"
+
+// Recorder is an input.Source that records every action it passes through
+type Recorder struct {
+	src    input.Source
+	replay Replay
+}
+
+// NewRecorder records the actions produced by src for a run with the given seed
+func NewRecorder(src input.Source, seed int64) *Recorder {
+	return &Recorder{
+		src:    src,
+		replay: Replay{Seed: seed},
+	}
+}
+
+// SetSetup records the config and level the run is played with
+func (r *Recorder) SetSetup(s Setup) {
+	r.replay.Setup = s
+}
+
+// SetHighScores records the scores in the high-score table as the run
+// starts, so playback can decide the same runs qualify
+func (r *Recorder) SetHighScores(scores []int) {
+	r.replay.HighScores = append([]int(nil), scores...)
+}
+
+// Poll reads the wrapped source and records the result
+func (r *Recorder) Poll() input.Action {
+	a := r.src.Poll()
+	r.replay.Actions = append(r.replay.Actions, a)
+	return a
+}
+
+// Finish returns the recording, stamped with the final state of the run
+func (r *Recorder) Finish(final State) *Replay {
+	rep := r.replay
+	rep.Actions = append([]input.Action(nil), r.replay.Actions...)
+	rep.Final = final
+	return &rep
+}
+
+// Playback is an input.Source that feeds a recorded run back tick by tick
+type Playback struct {
+	replay *Replay
+	pos    int
+}
+
+// NewPlayback plays back a replay
+func NewPlayback(r *Replay) *Playback {
+	return &Playback{replay: r}
+}
+
+// Poll returns the recorded actions for the next tick
+func (p *Playback) Poll() input.Action {
+	if p.Done() {
+		return input.None
+	}
+	a := p.replay.Actions[p.pos]
+	p.pos++
+	return a
+}
+
+// Done reports whether every recorded tick has been played
+func (p *Playback) Done() bool {
+	return p.pos >= len(p.replay.Actions)
+}
@@ -0,0 +1,228 @@
+// Package scoring works out what the player's actions are worth: kill
+// combos and their multiplier, wave bonuses for flying clean and shooting
+// straight, and grazing enemy bullets.
+package scoring
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+// Source is where a share of the score came from
+type Source int
+
+const (
+	SourceKills Source = iota
+	SourceCombo
+	SourceNoDamage
+	SourceAccuracy
+	SourceGraze
+	SourcePowerUp
+	sourceCount
+)
+
+// Sources lists every score source in the order the breakdown shows them
+var Sources = []Source{SourceKills, SourceCombo, SourceNoDamage, SourceAccuracy, SourceGraze, SourcePowerUp}
+
+// String returns the source's label for the game over breakdown
+func (s Source) String() string {
+	switch s {
+	case SourceKills:
+		return "KILLS"
+	case SourceCombo:
+		return "COMBO"
+	case SourceNoDamage:
+		return "NO DAMAGE"
+	case SourceAccuracy:
+		return "ACCURACY"
+	case SourceGraze:
+		return "GRAZE"
+	case SourcePowerUp:
+		return "POWER-UP"
+	}
+	return "UNKNOWN"
+}
+
+// Breakdown holds the points earned from each source
+type Breakdown [sourceCount]int
+
+// Total returns the points earned from every source together
+func (b Breakdown) Total() int {
+	total := 0
+	for _, points := range b {
+		total += points
+	}
+	return total
+}
+
+// Popup is a score shown floating up from where it was earned
+type Popup struct {
+	Text     string
+	Position vector.Vector2
+	Age      float64
+}
+
+// popupRise is how fast popups float up, in pixels per second
+const popupRise = 40.0
+
+// Scorer tracks a run's score sources, its kill chain and the current
+// wave's bonus tallies
+type Scorer struct {
+	cfg config.ScoringConfig
+
+	combo      int
+	comboTimer float64
+	bestCombo  int
+	kills      int
+	breakdown  Breakdown
+	popups     []Popup
+
+	// The current wave, for its bonuses
+	hurt  bool
+	shots int
+	hits  int
+}
+
+// NewScorer creates a scorer for a new run
+func NewScorer(cfg config.ScoringConfig) *Scorer {
+	return &Scorer{cfg: cfg}
+}
+
+// Update runs down the combo window and floats popups away
+func (s *Scorer) Update(dt float64) {
+	if s.comboTimer > 0 {
+		s.comboTimer -= dt
+		if s.comboTimer <= 0 {
+			s.combo = 0
+		}
+	}
+
+	for i := len(s.popups) - 1; i >= 0; i-- {
+		p := &s.popups[i]
+		p.Age += dt
+		p.Position.Y -= popupRise * dt
+		if p.Age >= s.cfg.PopupTime {
+			s.popups = append(s.popups[:i], s.popups[i+1:]...)
+		}
+	}
+}
+
+// Kill chains a kill worth points at the given position into the combo
+// and returns the points it earns under the combo's multiplier
+func (s *Scorer) Kill(points int, at vector.Vector2) int {
+	if s.comboTimer <= 0 {
+		s.combo = 0
+	}
+	s.combo++
+	s.kills++
+	s.comboTimer = s.cfg.ComboWindow
+	s.bestCombo = max(s.bestCombo, s.combo)
+
+	multiplier := s.Multiplier()
+	total := points * multiplier
+	s.breakdown[SourceKills] += points
+	s.breakdown[SourceCombo] += total - points
+
+	text := fmt.Sprintf("+%d", total)
+	if multiplier > 1 {
+		text += fmt.Sprintf(" x%d", multiplier)
+	}
+	s.popup(text, at)
+	return total
+}
+
+// Multiplier returns the kill multiplier the current combo has reached
+func (s *Scorer) Multiplier() int {
+	return min(1+s.combo/s.cfg.ComboStep, s.cfg.MaxMultiplier)
+}
+
+// Combo returns the number of kills in the current chain
+func (s *Scorer) Combo() int {
+	return s.combo
+}
+
+// BestCombo returns the longest chain of the run
+func (s *Scorer) BestCombo() int {
+	return s.bestCombo
+}
+
+// Kills returns the number of enemies and bosses shot down in the run
+func (s *Scorer) Kills() int {
+	return s.kills
+}
+
+// Hurt breaks the combo and loses the current wave's no-damage bonus
+func (s *Scorer) Hurt() {
+	s.combo = 0
+	s.comboTimer = 0
+	s.hurt = true
+}
+
+// ShotFired records a shot toward the wave's accuracy
+func (s *Scorer) ShotFired() {
+	s.shots++
+}
+
+// ShotHit records a shot hitting toward the wave's accuracy
+func (s *Scorer) ShotHit() {
+	s.hits++
+}
+
+// Graze awards the points for an enemy bullet passing close by at the
+// given position
+func (s *Scorer) Graze(at vector.Vector2) int {
+	if s.cfg.GrazePoints == 0 {
+		return 0
+	}
+	s.breakdown[SourceGraze] += s.cfg.GrazePoints
+	s.popup(fmt.Sprintf("GRAZE +%d", s.cfg.GrazePoints), at)
+	return s.cfg.GrazePoints
+}
+
+// WaveCleared awards the bonuses the wave just cleared has earned,
+// announcing them at the given position, and starts tallying the next
+func (s *Scorer) WaveCleared(at vector.Vector2) int {
+	bonus := 0
+	if !s.hurt && s.cfg.NoDamageBonus > 0 {
+		bonus += s.cfg.NoDamageBonus
+		s.breakdown[SourceNoDamage] += s.cfg.NoDamageBonus
+		s.popup(fmt.Sprintf("NO DAMAGE +%d", s.cfg.NoDamageBonus), at)
+		at.Y += 15
+	}
+	if s.shots > 0 {
+		rate := float64(s.hits) / float64(s.shots)
+		if points := int(math.Round(float64(s.cfg.AccuracyBonus) * rate)); rate >= s.cfg.AccuracyThreshold && points > 0 {
+			bonus += points
+			s.breakdown[SourceAccuracy] += points
+			s.popup(fmt.Sprintf("ACCURACY %.0f%% +%d", rate*100, points), at)
+		}
+	}
+
+	s.hurt = false
+	s.shots = 0
+	s.hits = 0
+	return bonus
+}
+
+// Record adds points earned outside the scorer, such as a score
+// power-up's boost, to the breakdown
+func (s *Scorer) Record(source Source, points int) {
+	s.breakdown[source] += points
+}
+
+// Breakdown returns the points earned from each source so far
+func (s *Scorer) Breakdown() Breakdown {
+	return s.breakdown
+}
+
+// Popups returns the score popups still showing
+func (s *Scorer) Popups() []Popup {
+	return s.popups
+}
+
+func (s *Scorer) popup(text string, at vector.Vector2) {
+	s.popups = append(s.popups, Popup{Text: text, Position: at})
+}
@@ -0,0 +1,144 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/EchoSingh/space-shooter/internal/config"
+	"github.com/EchoSingh/space-shooter/pkg/vector"
+)
+
+const dt = 1.0 / 60
+
+var here = vector.New(100, 100)
+
+func TestCombosRaiseTheMultiplier(t *testing.T) {
+	cfg := config.Default().Scoring
+	s := NewScorer(cfg)
+
+	total := 0
+	for i := 0; i < cfg.ComboStep*2; i++ {
+		total += s.Kill(10, here)
+		s.Update(dt)
+	}
+
+	if s.Combo() != cfg.ComboStep*2 || s.Multiplier() != 3 {
+		t.Errorf("Expected a %d kill chain at x3, got %d at x%d", cfg.ComboStep*2, s.Combo(), s.Multiplier())
+	}
+	b := s.Breakdown()
+	if b[SourceKills] != 10*cfg.ComboStep*2 || b.Total() != total {
+		t.Errorf("Expected kills and combo to add up to %d, got %+v", total, b)
+	}
+	if b[SourceCombo] == 0 {
+		t.Error("Expected the chain to earn combo points")
+	}
+}
+
+func TestComboEndsAfterWindow(t *testing.T) {
+	cfg := config.Default().Scoring
+	s := NewScorer(cfg)
+
+	for i := 0; i < cfg.ComboStep; i++ {
+		s.Kill(10, here)
+	}
+	for elapsed := 0.0; elapsed <= cfg.ComboWindow; elapsed += dt {
+		s.Update(dt)
+	}
+
+	if s.Combo() != 0 || s.Multiplier() != 1 {
+		t.Errorf("Expected the chain to lapse, got %d at x%d", s.Combo(), s.Multiplier())
+	}
+	if got := s.Kill(10, here); got != 10 {
+		t.Errorf("Expected a fresh chain to score 10, got %d", got)
+	}
+	if s.BestCombo() != cfg.ComboStep {
+		t.Errorf("Expected a best combo of %d, got %d", cfg.ComboStep, s.BestCombo())
+	}
+}
+
+func TestMultiplierIsCapped(t *testing.T) {
+	cfg := config.Default().Scoring
+	s := NewScorer(cfg)
+
+	for i := 0; i < cfg.ComboStep*cfg.MaxMultiplier*2; i++ {
+		s.Kill(10, here)
+	}
+	if s.Multiplier() != cfg.MaxMultiplier {
+		t.Errorf("Expected x%d at most, got x%d", cfg.MaxMultiplier, s.Multiplier())
+	}
+}
+
+func TestWaveBonuses(t *testing.T) {
+	cfg := config.Default().Scoring
+
+	tests := []struct {
+		name  string
+		shots int
+		hits  int
+		hurt  bool
+		want  int
+	}{
+		{"clean and accurate", 10, 8, false, cfg.NoDamageBonus + 240},
+		{"clean but wild", 10, 2, false, cfg.NoDamageBonus},
+		{"hurt and accurate", 10, 10, true, cfg.AccuracyBonus},
+		{"hurt without shooting", 0, 0, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScorer(cfg)
+			for i := 0; i < tt.shots; i++ {
+				s.ShotFired()
+			}
+			for i := 0; i < tt.hits; i++ {
+				s.ShotHit()
+			}
+			if tt.hurt {
+				s.Hurt()
+			}
+
+			if got := s.WaveCleared(here); got != tt.want {
+				t.Errorf("Expected a bonus of %d, got %d", tt.want, got)
+			}
+			if got := s.Breakdown().Total(); got != tt.want {
+				t.Errorf("Expected the breakdown to total %d, got %d", tt.want, got)
+			}
+
+			// The next wave starts with a clean slate
+			if got := s.WaveCleared(here); got != cfg.NoDamageBonus {
+				t.Errorf("Expected only the no-damage bonus for an empty wave, got %d", got)
+			}
+		})
+	}
+}
+
+func TestHurtBreaksCombo(t *testing.T) {
+	s := NewScorer(config.Default().Scoring)
+	s.Kill(10, here)
+	s.Kill(10, here)
+	s.Hurt()
+
+	if s.Combo() != 0 {
+		t.Errorf("Expected taking damage to break the chain, got %d", s.Combo())
+	}
+}
+
+func TestPopupsFloatAway(t *testing.T) {
+	cfg := config.Default().Scoring
+	s := NewScorer(cfg)
+	s.Kill(10, here)
+	s.Graze(here)
+
+	if len(s.Popups()) != 2 {
+		t.Fatalf("Expected a popup for the kill and the graze, got %d", len(s.Popups()))
+	}
+	s.Update(dt)
+	if p := s.Popups()[0]; p.Position.Y >= here.Y || p.Text != "+10" {
+		t.Errorf("Expected +10 to float up from %v, got %+v", here, p)
+	}
+
+	for elapsed := 0.0; elapsed <= cfg.PopupTime; elapsed += dt {
+		s.Update(dt)
+	}
+	if len(s.Popups()) != 0 {
+		t.Errorf("Expected popups gone after %gs, got %d", cfg.PopupTime, len(s.Popups()))
+	}
+}
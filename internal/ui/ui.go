@@ -3,7 +3,11 @@ package ui
 import (
 	"fmt"
 	"image/color"
+	"math"
+	"strings"
+	"time"
 
+	"github.com/EchoSingh/space-shooter/internal/render"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
@@ -22,19 +26,130 @@ func NewUI(screenWidth, screenHeight int) *UI {
 	}
 }
 
+// HUD holds the values shown by DrawHUD
+type HUD struct {
+	Score      int
+	Health     int
+	Lives      int
+	Wave       string
+	Difficulty float64
+	Combo      int
+	Multiplier int
+	Effects    []ActiveEffect
+}
+
+// ActiveEffect is a running power-up effect and its seconds left
+type ActiveEffect struct {
+	Label     string
+	Remaining float64
+}
+
+// ScoreLine is one source of points in the game over breakdown
+type ScoreLine struct {
+	Label  string
+	Points int
+}
+
+// HighScore is one row of the high-score screen
+type HighScore struct {
+	Name     string
+	Score    int
+	Kills    int
+	Duration float64
+	Date     time.Time
+}
+
 // DrawHUD draws the game HUD
-func (u *UI) DrawHUD(screen *ebiten.Image, score, health int) {
+func (u *UI) DrawHUD(screen *ebiten.Image, hud HUD) {
 	// Score
-	scoreText := fmt.Sprintf("SCORE: %d", score)
+	scoreText := fmt.Sprintf("SCORE: %d", hud.Score)
 	ebitenutil.DebugPrintAt(screen, scoreText, 10, 10)
 
 	// Health
-	healthText := fmt.Sprintf("HEALTH: %d", health)
+	healthText := fmt.Sprintf("HEALTH: %d", hud.Health)
 	ebitenutil.DebugPrintAt(screen, healthText, 10, 25)
 
+	// Lives
+	livesText := fmt.Sprintf("LIVES: %d", hud.Lives)
+	ebitenutil.DebugPrintAt(screen, livesText, 10, 40)
+
+	// Active power-ups
+	for i, effect := range hud.Effects {
+		effectText := fmt.Sprintf("%s %.0fs", effect.Label, math.Ceil(effect.Remaining))
+		ebitenutil.DebugPrintAt(screen, effectText, 10, 60+i*15)
+	}
+
+	// Wave progress
+	if hud.Wave != "" {
+		ebitenutil.DebugPrintAt(screen, hud.Wave, u.screenWidth/2-len(hud.Wave)*3, 10)
+	}
+
 	// FPS
 	fpsText := fmt.Sprintf("FPS: %.0f", ebiten.ActualFPS())
 	ebitenutil.DebugPrintAt(screen, fpsText, u.screenWidth-100, 10)
+
+	// Difficulty level
+	difficultyText := fmt.Sprintf("THREAT: x%.1f", hud.Difficulty)
+	ebitenutil.DebugPrintAt(screen, difficultyText, u.screenWidth-100, 25)
+
+	// Kill chain, once there is one
+	if hud.Combo > 1 {
+		comboText := fmt.Sprintf("COMBO %d x%d", hud.Combo, hud.Multiplier)
+		ebitenutil.DebugPrintAt(screen, comboText, u.screenWidth-100, 40)
+	}
+}
+
+// DrawPopup draws floating score text centred on x, y
+func (u *UI) DrawPopup(screen *ebiten.Image, text string, x, y int) {
+	// DebugPrint glyphs are 6 pixels wide and 16 high
+	ebitenutil.DebugPrintAt(screen, text, x-len(text)*3, y-8)
+}
+
+// DrawBanner draws an announcement across the middle of the screen
+func (u *UI) DrawBanner(screen *ebiten.Image, text string) {
+	centerX := u.screenWidth / 2
+	centerY := u.screenHeight / 3
+
+	render.FillRect(screen, 0, float64(centerY-20), float64(u.screenWidth), 40, color.RGBA{R: 0, G: 0, B: 0, A: 140})
+
+	// DebugPrint glyphs are 6 pixels wide
+	ebitenutil.DebugPrintAt(screen, text, centerX-len(text)*3, centerY-8)
+}
+
+// DrawBossBar draws a boss's name, remaining health and shield charge
+// across the bottom of the screen
+func (u *UI) DrawBossBar(screen *ebiten.Image, name string, fraction, shield float64) {
+	const margin, height = 20, 8
+	width := u.screenWidth - 2*margin
+	y := u.screenHeight - margin - height
+
+	render.FillRect(screen, margin, float64(y), float64(width), height, color.RGBA{R: 60, G: 20, B: 20, A: 200})
+
+	filled := math.Floor(float64(width) * math.Max(0, math.Min(1, fraction)))
+	render.FillRect(screen, margin, float64(y), filled, height, color.RGBA{R: 220, G: 40, B: 60, A: 255})
+
+	// The shield runs as a thin strip along the top of the health bar
+	charged := math.Floor(float64(width) * math.Max(0, math.Min(1, shield)))
+	render.FillRect(screen, margin, float64(y), charged, 2, color.RGBA{R: 80, G: 160, B: 255, A: 255})
+
+	ebitenutil.DebugPrintAt(screen, strings.ToUpper(name), margin, y-16)
+}
+
+// DrawBossWarning flashes a warning that a boss is arriving, elapsed
+// seconds into the warning
+func (u *UI) DrawBossWarning(screen *ebiten.Image, name string, elapsed float64) {
+	centerX := u.screenWidth / 2
+	centerY := u.screenHeight / 3
+
+	// The band pulses and the text blinks four times a second
+	alpha := uint8(90 + 60*math.Abs(math.Sin(elapsed*math.Pi*2)))
+	render.FillRect(screen, 0, float64(centerY-25), float64(u.screenWidth), 50, color.RGBA{R: 140, G: 0, B: 0, A: alpha})
+
+	if int(elapsed*4)%2 == 0 {
+		ebitenutil.DebugPrintAt(screen, "WARNING", centerX-21, centerY-16)
+	}
+	text := strings.ToUpper(name) + " APPROACHING"
+	ebitenutil.DebugPrintAt(screen, text, centerX-len(text)*3, centerY)
 }
 
 // DrawMenu draws the main menu
@@ -50,7 +165,8 @@ func (u *UI) DrawMenu(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, "SPACE to Fire", centerX-60, centerY)
 	ebitenutil.DebugPrintAt(screen, "P to Pause", centerX-50, centerY+20)
 	ebitenutil.DebugPrintAt(screen, "Press ENTER to Start", centerX-80, centerY+60)
-	ebitenutil.DebugPrintAt(screen, "Press ESC to Quit", centerX-70, centerY+80)
+	ebitenutil.DebugPrintAt(screen, "Press H for High Scores", centerX-90, centerY+80)
+	ebitenutil.DebugPrintAt(screen, "Press ESC to Quit", centerX-70, centerY+100)
 }
 
 // DrawPauseMenu draws the pause menu
@@ -59,29 +175,108 @@ func (u *UI) DrawPauseMenu(screen *ebiten.Image) {
 	centerY := u.screenHeight / 2
 
 	// Semi-transparent overlay
-	overlay := ebiten.NewImage(u.screenWidth, u.screenHeight)
-	overlay.Fill(color.RGBA{R: 0, G: 0, B: 0, A: 128})
-	screen.DrawImage(overlay, nil)
+	render.FillRect(screen, 0, 0, float64(u.screenWidth), float64(u.screenHeight), color.RGBA{R: 0, G: 0, B: 0, A: 128})
 
 	// Pause text and instruction
 	ebitenutil.DebugPrintAt(screen, "PAUSED", centerX-30, centerY-20)
 	ebitenutil.DebugPrintAt(screen, "Press P to Resume", centerX-70, centerY+20)
 }
 
-// DrawGameOver draws the game over screen
-func (u *UI) DrawGameOver(screen *ebiten.Image, score int) {
+// DrawGameOver draws the game over screen with the final score and where
+// it came from
+func (u *UI) DrawGameOver(screen *ebiten.Image, score int, lines []ScoreLine, seed int64) {
 	centerX := u.screenWidth / 2
 	centerY := u.screenHeight / 2
 
 	// Semi-transparent overlay
-	overlay := ebiten.NewImage(u.screenWidth, u.screenHeight)
-	overlay.Fill(color.RGBA{R: 0, G: 0, B: 0, A: 128})
-	screen.DrawImage(overlay, nil)
+	render.FillRect(screen, 0, 0, float64(u.screenWidth), float64(u.screenHeight), color.RGBA{R: 0, G: 0, B: 0, A: 128})
 
 	// Game Over text and score
 	ebitenutil.DebugPrintAt(screen, "GAME OVER", centerX-45, centerY-40)
 	scoreText := fmt.Sprintf("Final Score: %d", score)
 	ebitenutil.DebugPrintAt(screen, scoreText, centerX-60, centerY)
-	ebitenutil.DebugPrintAt(screen, "Press ENTER to Restart", centerX-90, centerY+40)
-	ebitenutil.DebugPrintAt(screen, "Press ESC for Menu", centerX-75, centerY+60)
+	y := centerY + 15
+	for _, line := range lines {
+		lineText := fmt.Sprintf("  %-10s %7d", line.Label, line.Points)
+		ebitenutil.DebugPrintAt(screen, lineText, centerX-60, y)
+		y += 15
+	}
+	seedText := fmt.Sprintf("Seed: %d", seed)
+	ebitenutil.DebugPrintAt(screen, seedText, centerX-60, y)
+	ebitenutil.DebugPrintAt(screen, "Press ENTER to Restart", centerX-90, y+25)
+	ebitenutil.DebugPrintAt(screen, "Press ESC for Menu", centerX-75, y+45)
+}
+
+// DrawNameEntry asks for a name for a run that made the high-score table,
+// with the character at cursor being edited, under the score and where it
+// came from
+func (u *UI) DrawNameEntry(screen *ebiten.Image, score int, lines []ScoreLine, name string, cursor int) {
+	centerX := u.screenWidth / 2
+	centerY := u.screenHeight / 2
+
+	// Semi-transparent overlay
+	render.FillRect(screen, 0, 0, float64(u.screenWidth), float64(u.screenHeight), color.RGBA{R: 0, G: 0, B: 0, A: 160})
+
+	// The breakdown pushes the name down the screen
+	top := centerY - 60 - len(lines)*15/2
+	ebitenutil.DebugPrintAt(screen, "NEW HIGH SCORE", centerX-42, top)
+	scoreText := fmt.Sprintf("%d", score)
+	ebitenutil.DebugPrintAt(screen, scoreText, centerX-len(scoreText)*3, top+20)
+	y := top + 35
+	for _, line := range lines {
+		lineText := fmt.Sprintf("  %-10s %7d", line.Label, line.Points)
+		ebitenutil.DebugPrintAt(screen, lineText, centerX-60, y)
+		y += 15
+	}
+
+	// Letters are spaced out with a marker under the one being edited
+	y += 15
+	left := centerX - len(name)*6
+	for i := 0; i < len(name); i++ {
+		ebitenutil.DebugPrintAt(screen, name[i:i+1], left+i*12, y)
+	}
+	ebitenutil.DebugPrintAt(screen, "^", left+cursor*12, y+14)
+
+	ebitenutil.DebugPrintAt(screen, "UP/DOWN to Change a Letter", centerX-78, y+40)
+	ebitenutil.DebugPrintAt(screen, "LEFT/RIGHT to Move", centerX-54, y+55)
+	ebitenutil.DebugPrintAt(screen, "Press ENTER to Save", centerX-57, y+75)
+}
+
+// DrawHighScores draws the high-score table, marking the row at
+// highlight (-1 for none) and any error saving it
+func (u *UI) DrawHighScores(screen *ebiten.Image, rows []HighScore, highlight int, saveErr error) {
+	centerX := u.screenWidth / 2
+	top := u.screenHeight/2 - 150
+
+	ebitenutil.DebugPrintAt(screen, "HIGH SCORES", centerX-33, top)
+
+	left := centerX - 171
+	y := top + 30
+	if len(rows) == 0 {
+		ebitenutil.DebugPrintAt(screen, "No scores yet", centerX-39, y)
+	} else {
+		header := fmt.Sprintf("   %-3s %-4s %8s %6s %6s  %s", "#", "NAME", "SCORE", "KILLS", "TIME", "DATE")
+		ebitenutil.DebugPrintAt(screen, header, left, y)
+	}
+	for i, row := range rows {
+		y += 16
+		marker := " "
+		if i == highlight {
+			marker = ">"
+		}
+		played := time.Duration(row.Duration * float64(time.Second)).Round(time.Second)
+		rowText := fmt.Sprintf("%s  %-3d %-4s %8d %6d %6s  %s",
+			marker, i+1, row.Name, row.Score, row.Kills, formatDuration(played), row.Date.Format("2006-01-02"))
+		ebitenutil.DebugPrintAt(screen, rowText, left, y)
+	}
+
+	if saveErr != nil {
+		ebitenutil.DebugPrintAt(screen, "Could not save high scores", centerX-78, y+30)
+	}
+	ebitenutil.DebugPrintAt(screen, "Press ENTER for Menu", centerX-60, y+50)
+}
+
+// formatDuration shows a play time as minutes and seconds
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
 }